@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"sakthiRathinam/oom-saver/pkg/process"
+	"sakthiRathinam/oom-saver/pkg/ui"
+)
+
+var adjustDryRun bool
+
+var adjustCmd = &cobra.Command{
+	Use:   "adjust [<PID> <VALUE>]",
+	Short: "Set oom_score_adj for a process or apply the policy's rules",
+	Long: `Set /proc/<pid>/oom_score_adj so the kernel OOM killer follows your priorities.
+
+With a PID and a value (-1000 to 1000), adjust that single process. Without
+arguments, apply the oom_score_adj rules from the policy file to every running
+process. The monitor re-applies the same rules on every tick.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return fmt.Errorf("accepts either no arguments or <PID> <VALUE>")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 2 {
+			pid, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid PID: %s", args[0])
+			}
+			value, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid oom_score_adj value: %s", args[1])
+			}
+
+			proc, err := process.GetProcessByPID(pid)
+			if err != nil {
+				return fmt.Errorf("%s %w", ui.Red("✗"), err)
+			}
+
+			if adjustDryRun {
+				fmt.Printf("%s Would set oom_score_adj of PID %d (%s): %d → %d\n", ui.Cyan("ℹ️"), pid, proc.Name, proc.OOMScoreAdj, value)
+				return nil
+			}
+
+			if err := process.SetOOMScoreAdj(pid, value); err != nil {
+				return fmt.Errorf("%s %w", ui.Red("✗"), err)
+			}
+			fmt.Printf("%s Set oom_score_adj of PID %d (%s): %d → %d\n", ui.Green("✓"), pid, proc.Name, proc.OOMScoreAdj, value)
+			return nil
+		}
+
+		if len(loadedPolicy.OOMScoreAdj) == 0 {
+			fmt.Printf("%s The policy defines no oom_score_adj rules\n", ui.Yellow("⚠️"))
+			return nil
+		}
+
+		processes, err := process.GetAllRunningProcesses()
+		if err != nil {
+			return fmt.Errorf("failed to get processes: %w", err)
+		}
+
+		results := process.ApplyOOMScoreAdj(processes, loadedPolicy.OOMScoreAdj, adjustDryRun)
+		if len(results) == 0 {
+			fmt.Printf("%s All matching processes already have the configured oom_score_adj\n", ui.Green("✓"))
+			return nil
+		}
+		printAdjustResults(results, adjustDryRun)
+		return nil
+	},
+}
+
+func printAdjustResults(results []process.AdjustResult, dryRun bool) {
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			fmt.Printf("%s %v\n", ui.Red("✗"), r.Err)
+		case dryRun:
+			fmt.Printf("%s Would set oom_score_adj of PID %d (%s): %d → %d [%s]\n",
+				ui.Cyan("ℹ️"), r.Process.PID, r.Process.Name, r.OldValue, r.NewValue, r.Rule)
+		default:
+			fmt.Printf("%s Set oom_score_adj of PID %d (%s): %d → %d [%s]\n",
+				ui.Green("✓"), r.Process.PID, r.Process.Name, r.OldValue, r.NewValue, r.Rule)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(adjustCmd)
+	adjustCmd.Flags().BoolVarP(&adjustDryRun, "dry-run", "n", false, "Show what would change without writing anything")
+}
@@ -35,10 +35,37 @@ var classifyCmd = &cobra.Command{
 		fmt.Println(ui.Cyan("───────────────────────────────────────────────────────────────────"))
 		fmt.Printf("  PID:             %s\n", ui.Bold(fmt.Sprintf("%d", proc.PID)))
 		fmt.Printf("  Name:            %s\n", ui.Bold(proc.Name))
+		fmt.Printf("  Executable:      %s\n", orUnknown(proc.Exe))
+		fmt.Printf("  Command line:    %s\n", orUnknown(proc.Cmdline))
 		fmt.Printf("  Status:          %s\n", statusColor(proc.Status))
 		fmt.Printf("  Owner (UID):     %d\n", proc.UID)
 		fmt.Printf("  Parent PID:      %d\n", proc.PPID)
 		fmt.Printf("  OOM Score:       %d\n", proc.OOMScore)
+		fmt.Printf("  OOM Score Adj:   %d\n", proc.OOMScoreAdj)
+
+		fmt.Printf("\n%s\n", ui.Bold("Systemd"))
+		fmt.Println(ui.Cyan("───────────────────────────────────────────────────────────────────"))
+		fmt.Printf("  Unit:            %s\n", orUnknown(proc.Unit))
+		fmt.Printf("  Slice:           %s\n", orUnknown(proc.Slice))
+		fmt.Printf("  Cgroup:          %s\n", orUnknown(proc.Cgroup))
+		if proc.Unit != "" && !proc.IsUserUnit() {
+			if props, err := process.LookupUnitProperties(proc.Unit); err == nil {
+				fmt.Printf("  OOMPolicy:       %s\n", orUnknown(props.OOMPolicy))
+				fmt.Printf("  ManagedOOMPref:  %s\n", orUnknown(props.ManagedOOMPreference))
+			}
+		}
+
+		fmt.Printf("\n%s\n", ui.Bold("Container"))
+		fmt.Println(ui.Cyan("───────────────────────────────────────────────────────────────────"))
+		fmt.Printf("  Container:       %s\n", proc.Container)
+		if proc.Container.ID != "" {
+			fmt.Printf("  Runtime:         %s\n", proc.Container.Runtime)
+			fmt.Printf("  Container ID:    %s\n", proc.Container.ID)
+		}
+		if proc.Container.PodUID != "" {
+			fmt.Printf("  Pod UID:         %s\n", proc.Container.PodUID)
+			fmt.Printf("  QoS Class:       %s\n", proc.Container.QoSClass)
+		}
 
 		fmt.Printf("\n%s\n", ui.Bold("Safety Classification"))
 		fmt.Println(ui.Cyan("───────────────────────────────────────────────────────────────────"))
@@ -48,64 +75,46 @@ var classifyCmd = &cobra.Command{
 		fmt.Println(ui.Cyan("───────────────────────────────────────────────────────────────────"))
 
 		switch proc.SafetyLevel {
-		case "critical":
+		case process.SafetyCritical:
 			fmt.Printf("  %s %s\n", ui.RedBold("🔴 CRITICAL PROCESS"), ui.RedBold("- DO NOT KILL"))
 			fmt.Println("  This is a system-critical process. Killing it may:")
 			fmt.Println("    • Crash the entire system")
 			fmt.Println("    • Cause data loss or corruption")
 			fmt.Println("    • Require a system reboot")
-			fmt.Println()
-			fmt.Println("  Reasons for classification:")
-			if proc.PID == 1 {
-				fmt.Println("    • PID 1 (init/systemd) - system manager")
-			}
-			if proc.OOMScore < -500 {
-				fmt.Printf("    • Very negative OOM score (%d) - kernel protected\n", proc.OOMScore)
-			}
-			fmt.Println("    • Essential system service")
 
-		case "important":
+		case process.SafetyImportant:
 			fmt.Printf("  %s %s\n", ui.Yellow("🟡 IMPORTANT PROCESS"), ui.Yellow("- Kill with caution"))
 			fmt.Println("  This is an important system process. Killing it may:")
 			fmt.Println("    • Disrupt system services")
 			fmt.Println("    • Affect running applications")
 			fmt.Println("    • Require service restart")
-			fmt.Println()
-			fmt.Println("  Reasons for classification:")
-			if proc.UID == 0 {
-				fmt.Println("    • Owned by root")
-			}
-			if proc.PPID == 1 {
-				fmt.Println("    • Child of systemd")
-			}
-			fmt.Println("    • System daemon or important service")
 
-		case "safe":
+		case process.SafetySafe:
 			fmt.Printf("  %s %s\n", ui.Green("🟢 SAFE TO KILL"), ui.Green("- Can be terminated"))
 			fmt.Println("  This process can be safely killed. It is likely:")
 			fmt.Println("    • A user application")
 			fmt.Println("    • Non-critical to system operation")
 			fmt.Println("    • Safe to restart if needed")
-			fmt.Println()
-			fmt.Println("  Reasons for classification:")
-			if proc.Status == "zombie" {
-				fmt.Println("    • Zombie process (already dead)")
-			}
-			if proc.UID >= 1000 {
-				fmt.Println("    • Owned by regular user (non-root)")
-			}
-			if proc.OOMScore > 300 {
-				fmt.Printf("    • High OOM score (%d) - kernel considers killable\n", proc.OOMScore)
-			}
 
-		case "unknown":
+		case process.SafetyUnknown:
 			fmt.Printf("  %s %s\n", ui.White("⚪ UNKNOWN"), ui.White("- Requires investigation"))
 			fmt.Println("  This process doesn't clearly fit other categories.")
 			fmt.Println("  Manual investigation recommended before killing.")
-			fmt.Println()
-			fmt.Printf("  UID:       %d\n", proc.UID)
-			fmt.Printf("  PPID:      %d\n", proc.PPID)
-			fmt.Printf("  OOM Score: %d\n", proc.OOMScore)
+		}
+
+		matched := proc.Classification.MatchedRule()
+		fmt.Println()
+		fmt.Println("  Reason for classification:")
+		fmt.Printf("    • %s %s\n", matched.Detail, ui.Cyan("("+matched.Rule+")"))
+
+		fmt.Printf("\n%s\n", ui.Bold("Rules Evaluated"))
+		fmt.Println(ui.Cyan("───────────────────────────────────────────────────────────────────"))
+		for i, rule := range proc.Classification.Rules {
+			mark := ui.White("✗")
+			if rule.Matched {
+				mark = ui.Green("✓")
+			}
+			fmt.Printf("  %2d. %s %-22s → %s\n", i+1, mark, rule.Rule, ui.GetSafetyColor(rule.Level)(rule.Level))
 		}
 
 		fmt.Println()
@@ -113,6 +122,13 @@ var classifyCmd = &cobra.Command{
 	},
 }
 
+func orUnknown(s string) string {
+	if s == "" {
+		return ui.White("(unavailable)")
+	}
+	return s
+}
+
 func init() {
 	rootCmd.AddCommand(classifyCmd)
 }
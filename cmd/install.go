@@ -137,6 +137,8 @@ func generateSystemdService(settings CleanupSettings) string {
 		cmdFlags = append(cmdFlags, fmt.Sprintf("--interval=%ds", settings.Interval))
 	}
 
+	cmdFlags = append(cmdFlags, "--audit-log=/var/log/oom-saver/audit.log")
+
 	execStart := "/usr/local/bin/oom-saver " + strings.Join(cmdFlags, " ")
 
 	return fmt.Sprintf(`[Unit]
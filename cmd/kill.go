@@ -9,13 +9,15 @@ import (
 	"syscall"
 
 	"github.com/spf13/cobra"
+	"sakthiRathinam/oom-saver/pkg/audit"
 	"sakthiRathinam/oom-saver/pkg/process"
 	"sakthiRathinam/oom-saver/pkg/ui"
 )
 
 var (
-	killSignal string
-	killForce  bool
+	killSignal   string
+	killForce    bool
+	killAuditLog string
 )
 
 var killCmd = &cobra.Command{
@@ -54,14 +56,14 @@ var killCmd = &cobra.Command{
 		fmt.Printf("  Safety: %s %s\n", safetyIcon, safetyColor(proc.SafetyLevel))
 		fmt.Println()
 
-		if proc.SafetyLevel == "critical" && !killForce {
+		if proc.SafetyLevel == process.SafetyCritical && !killForce {
 			fmt.Printf("%s Cannot kill CRITICAL process without --force flag!\n", ui.RedBold("⛔"))
 			fmt.Printf("%s This is a system-critical process. Killing it may crash your system.\n", ui.Red("⚠️"))
 			fmt.Printf("%s Use --force flag only if you know what you're doing.\n\n", ui.Yellow("💡"))
 			return fmt.Errorf("safety check failed")
 		}
 
-		if proc.SafetyLevel == "critical" && killForce {
+		if proc.SafetyLevel == process.SafetyCritical && killForce {
 			fmt.Printf("%s %s KILLING CRITICAL PROCESS!\n", ui.RedBold("⛔"), ui.RedBold("WARNING:"))
 			fmt.Printf("%s This may CRASH your system or cause data loss!\n", ui.Red("⚠️"))
 			fmt.Print(ui.RedBold("Type 'I UNDERSTAND THE RISK' to continue: "))
@@ -74,7 +76,7 @@ var killCmd = &cobra.Command{
 				fmt.Println(ui.Yellow("✗ Cancelled"))
 				return nil
 			}
-		} else if proc.SafetyLevel == "important" {
+		} else if proc.SafetyLevel == process.SafetyImportant {
 			fmt.Printf("%s About to send %s to IMPORTANT process (PID %d)\n", ui.Yellow("⚠️"), ui.Bold(killSignal), pid)
 			fmt.Printf("%s This may affect system services or running applications.\n", ui.Yellow("⚠️"))
 			fmt.Print("Continue? (y/N): ")
@@ -102,6 +104,16 @@ var killCmd = &cobra.Command{
 		}
 
 		err = process.KillProcess(pid, sig)
+		if killAuditLog != "" {
+			logger, logErr := audit.Open(killAuditLog)
+			if logErr == nil {
+				logErr = logger.LogKill(process.KillEvent{Process: *proc, Reason: "manual", Signal: sig, Err: err})
+				logger.Close()
+			}
+			if logErr != nil {
+				fmt.Printf("%s %v\n", ui.Yellow("⚠️"), logErr)
+			}
+		}
 		if err != nil {
 			return fmt.Errorf("%s failed to kill process %d: %w", ui.Red("✗"), pid, err)
 		}
@@ -115,4 +127,5 @@ func init() {
 	rootCmd.AddCommand(killCmd)
 	killCmd.Flags().StringVarP(&killSignal, "signal", "s", "SIGTERM", "Signal to send (SIGTERM or SIGKILL)")
 	killCmd.Flags().BoolVarP(&killForce, "force", "f", false, "Force kill even for critical processes")
+	killCmd.Flags().StringVar(&killAuditLog, "audit-log", "", "Append a JSON line describing the kill to this file")
 }
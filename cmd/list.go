@@ -10,9 +10,11 @@ import (
 )
 
 var (
-	listLimit  int
-	listStatus string
-	listSafety string
+	listLimit   int
+	listStatus  string
+	listSafety  string
+	listExplain bool
+	listGroupBy string
 )
 
 var listCmd = &cobra.Command{
@@ -49,9 +51,14 @@ var listCmd = &cobra.Command{
 		}
 
 		if listSafety != "" {
+			level, err := process.ParseSafetyLevel(listSafety)
+			if err != nil {
+				return err
+			}
+
 			var filtered []process.Process
 			for _, p := range processes {
-				if p.SafetyLevel == listSafety {
+				if p.SafetyLevel == level {
 					filtered = append(filtered, p)
 				}
 			}
@@ -59,16 +66,37 @@ var listCmd = &cobra.Command{
 			fmt.Printf("%s Filtered to show only %s processes\n", ui.Cyan("ℹ️"), listSafety)
 		}
 
-		ui.PrintProcessTable(processes, listLimit)
+		switch listGroupBy {
+		case "":
+			printProcessList(processes)
+		case "container":
+			labels, groups := process.GroupByContainer(processes)
+			for _, label := range labels {
+				fmt.Printf("\n%s %s\n", ui.Cyan("📦 Container:"), ui.Bold(label))
+				printProcessList(groups[label])
+			}
+		default:
+			return fmt.Errorf("invalid --group-by value %q (use container)", listGroupBy)
+		}
 		fmt.Println()
 
 		return nil
 	},
 }
 
+func printProcessList(processes []process.Process) {
+	if listExplain {
+		ui.PrintExplainedProcessTable(processes, listLimit)
+	} else {
+		ui.PrintProcessTable(processes, listLimit)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().IntVarP(&listLimit, "limit", "l", 200, "Maximum number of processes to display")
 	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "Filter by status (e.g., zombie, running, sleeping)")
 	listCmd.Flags().StringVar(&listSafety, "safety", "", "Filter by safety level (critical, important, safe, unknown)")
+	listCmd.Flags().BoolVarP(&listExplain, "explain", "e", false, "Show the classification rule that decided each process's safety level")
+	listCmd.Flags().StringVarP(&listGroupBy, "group-by", "g", "", "Group processes (container)")
 }
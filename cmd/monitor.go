@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sys/unix"
+	"sakthiRathinam/oom-saver/pkg/audit"
+	"sakthiRathinam/oom-saver/pkg/freezer"
+	"sakthiRathinam/oom-saver/pkg/hooks"
 	"sakthiRathinam/oom-saver/pkg/memory"
 	"sakthiRathinam/oom-saver/pkg/process"
+	"sakthiRathinam/oom-saver/pkg/reclaim"
 	"sakthiRathinam/oom-saver/pkg/ui"
 )
 
@@ -25,9 +31,14 @@ var (
 	monitorMemoryAlert     bool
 	monitorMemoryThreshold int
 	monitorMemoryCooldown  int
+	monitorAuditLog        string
 )
 
-var memAlert *memory.MemoryAlert
+var (
+	memAlert      *memory.MemoryAlert
+	auditLogger   *audit.Logger
+	reclaimLadder *reclaim.Ladder
+)
 
 var monitorCmd = &cobra.Command{
 	Use:   "monitor",
@@ -65,6 +76,17 @@ var monitorCmd = &cobra.Command{
 			fmt.Printf("%s Auto-killing only SAFE zombies\n", ui.Green("✓"))
 		}
 
+		if loadedPolicy.Freeze.Enabled {
+			fmt.Printf("%s Freezing processes when available memory drops below %d MB (thaw above %d MB)\n",
+				ui.Cyan("ℹ️"), loadedPolicy.Freeze.BelowAvailableMB, loadedPolicy.Freeze.ThawAboveAvailableMB)
+		}
+
+		if loadedPolicy.Reclaim.Enabled {
+			reclaimLadder = reclaim.NewLadder(loadedPolicy.Reclaim)
+			fmt.Printf("%s Reclaim ladder enabled (%d steps below %d MB available, target %d MB)\n",
+				ui.Cyan("ℹ️"), len(loadedPolicy.Reclaim.Steps), loadedPolicy.Reclaim.BelowAvailableMB, loadedPolicy.Reclaim.TargetAvailableMB)
+		}
+
 		// Initialize memory alert if enabled
 		if monitorMemoryAlert {
 			memAlert = memory.NewMemoryAlert(monitorMemoryThreshold, monitorMemoryCooldown)
@@ -72,6 +94,16 @@ var monitorCmd = &cobra.Command{
 				ui.Cyan("ℹ️"), monitorMemoryThreshold, monitorMemoryCooldown)
 		}
 
+		if monitorAuditLog != "" {
+			logger, err := audit.Open(monitorAuditLog)
+			if err != nil {
+				return err
+			}
+			defer logger.Close()
+			auditLogger = logger
+			fmt.Printf("%s Recording kills to audit log %s\n", ui.Cyan("ℹ️"), monitorAuditLog)
+		}
+
 		ticker := time.NewTicker(monitorInterval)
 		defer ticker.Stop()
 
@@ -116,7 +148,33 @@ func killProcessToCleanUPMEM() {
 		return
 	}
 
-	if !monitorNoAutoKill {
+	// Frozen processes are paused instead of killed; keep them out of the
+	// kill pass but still show them in the table.
+	var frozen []process.Process
+	if loadedPolicy.Freeze.Enabled {
+		if state := freezeOnLowMemory(processes); state != nil {
+			var active []process.Process
+			for _, p := range processes {
+				if state.IsFrozen(p) {
+					frozen = append(frozen, p)
+				} else {
+					active = append(active, p)
+				}
+			}
+			processes = active
+		}
+	}
+
+	if len(loadedPolicy.OOMScoreAdj) > 0 {
+		printAdjustResults(process.ApplyOOMScoreAdj(processes, loadedPolicy.OOMScoreAdj, false), false)
+	}
+
+	killPass := !monitorNoAutoKill
+	if reclaimLadder != nil && !runReclaimLadder(processes) {
+		killPass = false
+	}
+
+	if killPass {
 		if monitorUseConfig {
 			// Use custom cleanup configuration
 			config := process.CleanupConfig{
@@ -126,6 +184,8 @@ func killProcessToCleanUPMEM() {
 				KillImportantLevel: monitorKillImportant,
 				MinOOMScore:        monitorMinOOMScore,
 				KillZombiesOnly:    monitorZombiesOnly,
+				PreKill:            runPreKillHooks,
+				OnKill:             recordKill,
 			}
 			processes, err = process.KillProcessWithConfig(processes, config)
 			if err != nil {
@@ -134,7 +194,7 @@ func killProcessToCleanUPMEM() {
 			}
 		} else {
 			// Use legacy zombie killing
-			processes, err = process.KillProcessIfZombie(processes, monitorAutoKillAll)
+			processes, err = process.KillProcessIfZombie(processes, monitorAutoKillAll, recordKill)
 			if err != nil {
 				fmt.Printf("%s Error killing zombies: %v\n", ui.Red("✗"), err)
 				return
@@ -142,10 +202,173 @@ func killProcessToCleanUPMEM() {
 		}
 	}
 
+	processes = append(processes, frozen...)
 	ui.PrintProcessTable(processes, monitorLimit)
 	fmt.Println()
 }
 
+// freezeOnLowMemory freezes the policy's preferred victims while available
+// memory is below the freeze threshold, and thaws them once it recovers.
+// It returns the freeze state, or nil if it could not be read.
+func freezeOnLowMemory(processes []process.Process) *freezer.State {
+	cfg := loadedPolicy.Freeze
+
+	memStats, err := memory.GetMemoryStats()
+	if err != nil {
+		fmt.Printf("%s Error fetching memory stats: %v\n", ui.Red("✗"), err)
+		return nil
+	}
+
+	state, err := freezer.LoadState(freezer.DefaultStatePath)
+	if err != nil {
+		fmt.Printf("%s %v\n", ui.Red("✗"), err)
+		return nil
+	}
+
+	switch {
+	case memStats.AvailableMB < cfg.BelowAvailableMB:
+		reason := fmt.Sprintf("available memory %d MB below %d MB", memStats.AvailableMB, cfg.BelowAvailableMB)
+		for _, p := range freezer.SelectCandidates(processes, cfg.Match, state, cfg.MaxPerTick) {
+			entry, err := state.Freeze(p, cfg.Method, reason)
+			if err != nil {
+				fmt.Printf("%s %v\n", ui.Red("✗"), err)
+				continue
+			}
+			fmt.Printf("%s Froze %s - %s\n", ui.Cyan("❄"), entry, reason)
+			notifyErr := memory.SendDesktopNotification("OOM-Saver",
+				fmt.Sprintf("Paused %s because memory is low. It will resume when memory recovers, or run 'oom-saver thaw %d'.", entry, entry.PID),
+				"normal")
+			if notifyErr != nil {
+				fmt.Printf("%s %v\n", ui.Yellow("⚠️"), notifyErr)
+			}
+		}
+
+	case memStats.AvailableMB >= cfg.ThawAboveAvailableMB && len(state.Entries) > 0:
+		thawed, err := state.ThawAll()
+		for _, e := range thawed {
+			fmt.Printf("%s Thawed %s - available memory recovered to %d MB\n", ui.Green("✓"), e, memStats.AvailableMB)
+		}
+		if err != nil {
+			fmt.Printf("%s %v\n", ui.Red("✗"), err)
+		}
+
+	default:
+		return state
+	}
+
+	if err := state.Save(); err != nil {
+		fmt.Printf("%s %v\n", ui.Red("✗"), err)
+	}
+	return state
+}
+
+// runReclaimLadder tries the policy's gentler reclaim actions when memory is
+// low. It reports whether the kill pass should still run, which is only the
+// case if the ladder could not bring memory back to its target.
+func runReclaimLadder(processes []process.Process) bool {
+	memStats, err := memory.GetMemoryStats()
+	if err != nil {
+		fmt.Printf("%s Error fetching memory stats: %v\n", ui.Red("✗"), err)
+		return true
+	}
+
+	if memStats.AvailableMB >= reclaimLadder.Config.TargetAvailableMB {
+		for _, r := range reclaimLadder.Restore() {
+			logReclaimResult(r)
+		}
+		return true
+	}
+
+	results, recovered := reclaimLadder.Run(memStats.AvailableMB, processes)
+	for _, r := range results {
+		logReclaimResult(r)
+	}
+
+	if len(results) > 0 && recovered {
+		fmt.Printf("%s Reclaim brought available memory back to target, skipping kills this tick\n", ui.Green("✓"))
+		return false
+	}
+	if len(results) > 0 {
+		fmt.Printf("%s Reclaim ladder exhausted, escalating to kills\n", ui.Yellow("⚠️"))
+	}
+	return true
+}
+
+func logReclaimResult(r reclaim.Result) {
+	if r.Err != nil {
+		fmt.Printf("%s Reclaim %s failed: %v\n", ui.Red("✗"), r, r.Err)
+	} else if r.AvailableMB > 0 {
+		fmt.Printf("%s Reclaim %s - %d MB available\n", ui.Cyan("♻"), r, r.AvailableMB)
+	} else {
+		fmt.Printf("%s Reclaim %s\n", ui.Cyan("♻"), r)
+	}
+
+	if auditLogger != nil {
+		if err := auditLogger.LogAction("reclaim", r.String(), r.Err); err != nil {
+			fmt.Printf("%s %v\n", ui.Yellow("⚠️"), err)
+		}
+	}
+}
+
+func recordKill(ev process.KillEvent) {
+	if !loadedPolicy.Hooks.IsEmpty() {
+		hookEvent := newHookEvent(ev.Process, ev.Reason)
+		hookEvent.Signal = unix.SignalName(ev.Signal)
+		if ev.Err != nil {
+			hookEvent.KillError = ev.Err.Error()
+		}
+		for _, err := range loadedPolicy.Hooks.RunPostKill(hookEvent) {
+			fmt.Printf("%s %v\n", ui.Yellow("⚠️"), err)
+		}
+	}
+
+	if auditLogger == nil {
+		return
+	}
+	if err := auditLogger.LogKill(ev); err != nil {
+		fmt.Printf("%s %v\n", ui.Yellow("⚠️"), err)
+	}
+}
+
+// runPreKillHooks runs the policy's pre-kill hooks. A veto is returned as an
+// error so KillProcessWithConfig keeps the process, and is audited.
+func runPreKillHooks(p process.Process, reason string) error {
+	if len(loadedPolicy.Hooks.PreKill) == 0 {
+		return nil
+	}
+
+	veto, errs := loadedPolicy.Hooks.RunPreKill(newHookEvent(p, reason))
+	for _, err := range errs {
+		fmt.Printf("%s %v\n", ui.Yellow("⚠️"), err)
+	}
+
+	if veto != nil && auditLogger != nil {
+		if err := auditLogger.Log(audit.NewEntry("veto", p, veto.Error())); err != nil {
+			fmt.Printf("%s %v\n", ui.Yellow("⚠️"), err)
+		}
+	}
+	return veto
+}
+
+func newHookEvent(p process.Process, reason string) hooks.Event {
+	ev := hooks.Event{
+		PID:         p.PID,
+		Name:        p.Name,
+		Exe:         p.Exe,
+		Cmdline:     p.Cmdline,
+		UID:         p.UID,
+		SafetyLevel: string(p.SafetyLevel),
+		Reason:      reason,
+		Signal:      unix.SignalName(syscall.SIGTERM),
+		OOMScore:    p.OOMScore,
+	}
+	if memStats, err := memory.GetMemoryStats(); err == nil {
+		ev.MemTotalMB = memStats.TotalMB
+		ev.MemAvailableMB = memStats.AvailableMB
+	}
+	return ev
+}
+
 func init() {
 	rootCmd.AddCommand(monitorCmd)
 	monitorCmd.Flags().DurationVarP(&monitorInterval, "interval", "i", 5*time.Second, "Monitoring interval")
@@ -166,4 +389,6 @@ func init() {
 	monitorCmd.Flags().BoolVar(&monitorMemoryAlert, "memory-alert", false, "Enable desktop notifications for low memory")
 	monitorCmd.Flags().IntVar(&monitorMemoryThreshold, "memory-threshold", 3, "Memory threshold in GB (alert when available memory is below this)")
 	monitorCmd.Flags().IntVar(&monitorMemoryCooldown, "memory-cooldown", 15, "Cooldown in minutes between memory alerts")
+
+	monitorCmd.Flags().StringVar(&monitorAuditLog, "audit-log", "", "Append a JSON line per killed process to this file")
 }
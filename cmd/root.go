@@ -5,6 +5,13 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"sakthiRathinam/oom-saver/pkg/policy"
+	"sakthiRathinam/oom-saver/pkg/process"
+)
+
+var (
+	policyPath   string
+	loadedPolicy = &policy.Policy{}
 )
 
 var rootCmd = &cobra.Command{
@@ -12,6 +19,30 @@ var rootCmd = &cobra.Command{
 	Short: "A beautiful OOM killer and process monitor for Linux",
 	Long: `oom-saver is a powerful process monitoring and management tool.
 It helps you monitor system processes, detect zombies, and prevent out-of-memory situations.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadPolicy()
+	},
+}
+
+// loadPolicy reads the policy file and installs its classifier chain. A
+// missing file is only an error when --policy was given explicitly.
+func loadPolicy() error {
+	var (
+		p   *policy.Policy
+		err error
+	)
+	if policyPath == "" {
+		p, err = policy.LoadDefault()
+	} else {
+		p, err = policy.Load(policyPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	loadedPolicy = p
+	process.DefaultClassifier = p.Classifier()
+	return nil
 }
 
 func Execute() {
@@ -23,4 +54,5 @@ func Execute() {
 
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.PersistentFlags().StringVar(&policyPath, "policy", "", "Policy file (default "+policy.DefaultPath+")")
 }
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sakthiRathinam/oom-saver/pkg/freezer"
+	"sakthiRathinam/oom-saver/pkg/ui"
+)
+
+var thawList bool
+
+var thawCmd = &cobra.Command{
+	Use:   "thaw [PID...]",
+	Short: "Resume processes frozen by the monitor",
+	Long: `Resume processes and cgroups the monitor froze under memory pressure.
+
+Without arguments, thaw everything oom-saver has frozen. With PIDs, thaw only
+those processes (any stopped process can be resumed this way).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		state, err := freezer.LoadState(freezer.DefaultStatePath)
+		if err != nil {
+			return err
+		}
+
+		if thawList {
+			if len(state.Entries) == 0 {
+				fmt.Printf("%s Nothing is frozen\n", ui.Green("✓"))
+				return nil
+			}
+			for _, e := range state.Entries {
+				fmt.Printf("  %s %s, frozen %s ago via %s: %s\n", ui.Cyan("❄"), e, time.Since(e.FrozenAt).Round(time.Second), e.Method, e.Reason)
+			}
+			return nil
+		}
+
+		if len(args) == 0 {
+			thawed, err := state.ThawAll()
+			for _, e := range thawed {
+				fmt.Printf("%s Thawed %s\n", ui.Green("✓"), e)
+			}
+			if saveErr := state.Save(); saveErr != nil && err == nil {
+				err = saveErr
+			}
+			if err != nil {
+				return fmt.Errorf("%s %w", ui.Red("✗"), err)
+			}
+			if len(thawed) == 0 {
+				fmt.Printf("%s Nothing is frozen\n", ui.Green("✓"))
+			}
+			return nil
+		}
+
+		for _, arg := range args {
+			pid, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid PID: %s", arg)
+			}
+			entry, err := state.ThawPID(pid)
+			if err != nil {
+				return fmt.Errorf("%s %w", ui.Red("✗"), err)
+			}
+			fmt.Printf("%s Thawed %s\n", ui.Green("✓"), entry)
+		}
+		return state.Save()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(thawCmd)
+	thawCmd.Flags().BoolVarP(&thawList, "list", "l", false, "List frozen processes instead of thawing them")
+}
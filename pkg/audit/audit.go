@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"sakthiRathinam/oom-saver/pkg/process"
+)
+
+// Entry is a single line in the audit log.
+type Entry struct {
+	Time           time.Time               `json:"time"`
+	Action         string                  `json:"action"`
+	PID            int                     `json:"pid,omitempty"`
+	Name           string                  `json:"name,omitempty"`
+	UID            int                     `json:"uid,omitempty"`
+	Reason         string                  `json:"reason,omitempty"`
+	Signal         string                  `json:"signal,omitempty"`
+	Error          string                  `json:"error,omitempty"`
+	Classification *process.Classification `json:"classification,omitempty"`
+}
+
+// Logger appends JSON-encoded entries to a file, one per line.
+type Logger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// Open opens (or creates) the audit log at path for appending.
+func Open(path string) (*Logger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return &Logger{file: file}, nil
+}
+
+// NewEntry builds an entry for an action taken against p.
+func NewEntry(action string, p process.Process, reason string) Entry {
+	return Entry{
+		Time:           time.Now(),
+		Action:         action,
+		PID:            p.PID,
+		Name:           p.Name,
+		UID:            p.UID,
+		Reason:         reason,
+		Classification: &p.Classification,
+	}
+}
+
+// Log writes an entry to the audit log.
+func (l *Logger) Log(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// LogKill records a signal sent by the automated cleanup functions.
+func (l *Logger) LogKill(ev process.KillEvent) error {
+	e := NewEntry("kill", ev.Process, ev.Reason)
+	e.Signal = unix.SignalName(ev.Signal)
+	if ev.Err != nil {
+		e.Error = ev.Err.Error()
+	}
+	return l.Log(e)
+}
+
+// LogAction records a system-wide action that is not tied to one process,
+// such as a reclaim step.
+func (l *Logger) LogAction(action string, reason string, actionErr error) error {
+	e := Entry{Time: time.Now(), Action: action, Reason: reason}
+	if actionErr != nil {
+		e.Error = actionErr.Error()
+	}
+	return l.Log(e)
+}
+
+// Close closes the underlying file.
+func (l *Logger) Close() error {
+	return l.file.Close()
+}
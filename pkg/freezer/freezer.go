@@ -0,0 +1,237 @@
+package freezer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"sakthiRathinam/oom-saver/pkg/process"
+)
+
+// DefaultStatePath records what oom-saver has frozen so a later thaw (from
+// the monitor or the thaw command) can undo it.
+const DefaultStatePath = "/var/lib/oom-saver/frozen.json"
+
+// cgroupRoot is where the cgroup v2 hierarchy is mounted.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// Freeze methods.
+const (
+	MethodCgroup = "cgroup"
+	MethodSignal = "signal"
+)
+
+// Entry is a frozen process or cgroup.
+type Entry struct {
+	PID      int       `json:"pid"`
+	Name     string    `json:"name"`
+	Method   string    `json:"method"`
+	Cgroup   string    `json:"cgroup,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+	FrozenAt time.Time `json:"frozen_at"`
+}
+
+// String describes the entry for log output.
+func (e Entry) String() string {
+	if e.Method == MethodCgroup {
+		return fmt.Sprintf("cgroup %s (PID %d, %s)", e.Cgroup, e.PID, e.Name)
+	}
+	return fmt.Sprintf("PID %d (%s)", e.PID, e.Name)
+}
+
+// State is the set of entries frozen by oom-saver, persisted as JSON.
+type State struct {
+	path    string
+	Entries []Entry `json:"entries"`
+}
+
+// LoadState reads the state file at path. A missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	s := &State{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read freeze state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse freeze state %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the state back to its file.
+func (s *State) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode freeze state: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write freeze state: %w", err)
+	}
+	return nil
+}
+
+// IsFrozen reports whether the process (or its cgroup) is recorded as frozen.
+func (s *State) IsFrozen(p process.Process) bool {
+	for _, e := range s.Entries {
+		if e.PID == p.PID || (e.Method == MethodCgroup && e.Cgroup == p.Cgroup) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanFreezeCgroup reports whether p's cgroup can be frozen on its own: the
+// host must use cgroup v2 and the cgroup must be a unit or container, never a
+// slice or the root.
+func CanFreezeCgroup(p process.Process) bool {
+	if p.Cgroup == "" || p.Cgroup == "/" {
+		return false
+	}
+	leaf := filepath.Base(p.Cgroup)
+	if !strings.HasSuffix(leaf, ".scope") && !strings.HasSuffix(leaf, ".service") && !p.Container.InContainer() {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(cgroupRoot, p.Cgroup, "cgroup.freeze"))
+	return err == nil
+}
+
+// Freeze stops p using method, falling back from cgroup to signal when the
+// cgroup cannot be frozen, and records it in the state.
+func (s *State) Freeze(p process.Process, method string, reason string) (Entry, error) {
+	entry := Entry{
+		PID:      p.PID,
+		Name:     p.Name,
+		Method:   MethodSignal,
+		Reason:   reason,
+		FrozenAt: time.Now(),
+	}
+
+	if method == MethodCgroup && CanFreezeCgroup(p) {
+		entry.Method = MethodCgroup
+		entry.Cgroup = p.Cgroup
+	}
+
+	if err := apply(entry, true); err != nil {
+		return entry, err
+	}
+
+	s.Entries = append(s.Entries, entry)
+	return entry, nil
+}
+
+// Thaw resumes an entry and removes it from the state.
+func (s *State) Thaw(entry Entry) error {
+	err := apply(entry, false)
+	if err != nil && !errors.Is(err, syscall.ESRCH) && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	for i, e := range s.Entries {
+		if e.PID == entry.PID && e.Method == entry.Method && e.Cgroup == entry.Cgroup {
+			s.Entries = append(s.Entries[:i], s.Entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// ThawAll resumes every recorded entry, most recently frozen first, and
+// returns the entries that were thawed.
+func (s *State) ThawAll() ([]Entry, error) {
+	var thawed []Entry
+	for len(s.Entries) > 0 {
+		entry := s.Entries[len(s.Entries)-1]
+		if err := s.Thaw(entry); err != nil {
+			return thawed, err
+		}
+		thawed = append(thawed, entry)
+	}
+	return thawed, nil
+}
+
+// ThawPID resumes pid. Processes oom-saver did not freeze are sent SIGCONT.
+func (s *State) ThawPID(pid int) (Entry, error) {
+	for _, e := range s.Entries {
+		if e.PID == pid {
+			return e, s.Thaw(e)
+		}
+	}
+
+	entry := Entry{PID: pid, Method: MethodSignal}
+	if err := syscall.Kill(pid, syscall.SIGCONT); err != nil {
+		return entry, fmt.Errorf("failed to thaw PID %d: %w", pid, err)
+	}
+	return entry, nil
+}
+
+func apply(entry Entry, freeze bool) error {
+	if entry.Method == MethodCgroup {
+		value := "0"
+		if freeze {
+			value = "1"
+		}
+		path := filepath.Join(cgroupRoot, entry.Cgroup, "cgroup.freeze")
+		if err := os.WriteFile(path, []byte(value), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		return nil
+	}
+
+	sig := syscall.SIGCONT
+	if freeze {
+		sig = syscall.SIGSTOP
+	}
+	if err := syscall.Kill(entry.PID, sig); err != nil {
+		return fmt.Errorf("failed to send %s to PID %d: %w", sig, entry.PID, err)
+	}
+	return nil
+}
+
+// SelectCandidates picks up to max processes to freeze: matching (any
+// process if match is empty), neither critical nor important, not already
+// frozen and not oom-saver itself, highest OOM score first.
+func SelectCandidates(processes []process.Process, match process.Matcher, state *State, max int) []process.Process {
+	self := os.Getpid()
+
+	var candidates []process.Process
+	for _, p := range processes {
+		if p.PID == self || p.Status == "zombie" || p.Status == "stopped" {
+			continue
+		}
+		if p.SafetyLevel == process.SafetyCritical || p.SafetyLevel == process.SafetyImportant {
+			continue
+		}
+		if !match.IsEmpty() && !match.Matches(&p) {
+			continue
+		}
+		if state.IsFrozen(p) {
+			continue
+		}
+		candidates = append(candidates, p)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].OOMScore > candidates[j].OOMScore
+	})
+
+	if max > 0 && len(candidates) > max {
+		candidates = candidates[:max]
+	}
+	return candidates
+}
@@ -0,0 +1,169 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// DefaultTimeout bounds how long a hook may run when none is configured.
+const DefaultTimeout = 10 * time.Second
+
+// Hook is an external command run around a kill.
+type Hook struct {
+	Name    string        `yaml:"name"`
+	Command []string      `yaml:"command"`
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Veto lets a pre-kill hook cancel the kill by exiting non-zero. Hooks
+	// that time out never veto.
+	Veto bool `yaml:"veto"`
+}
+
+// Config lists the hooks run before and after every automated kill.
+type Config struct {
+	Timeout  time.Duration `yaml:"timeout"`
+	PreKill  []Hook        `yaml:"pre_kill"`
+	PostKill []Hook        `yaml:"post_kill"`
+}
+
+// Event describes the kill a hook is run for. It is passed to the hook as
+// JSON on stdin and as OOM_SAVER_* environment variables.
+type Event struct {
+	Phase          string `json:"phase"`
+	PID            int    `json:"pid"`
+	Name           string `json:"name"`
+	Exe            string `json:"exe,omitempty"`
+	Cmdline        string `json:"cmdline,omitempty"`
+	UID            int    `json:"uid"`
+	SafetyLevel    string `json:"safety_level"`
+	Reason         string `json:"reason"`
+	Signal         string `json:"signal"`
+	OOMScore       int    `json:"oom_score"`
+	MemTotalMB     int    `json:"mem_total_mb"`
+	MemAvailableMB int    `json:"mem_available_mb"`
+	KillError      string `json:"kill_error,omitempty"`
+}
+
+func (e Event) environ() []string {
+	return append(os.Environ(),
+		"OOM_SAVER_PHASE="+e.Phase,
+		"OOM_SAVER_PID="+strconv.Itoa(e.PID),
+		"OOM_SAVER_NAME="+e.Name,
+		"OOM_SAVER_EXE="+e.Exe,
+		"OOM_SAVER_UID="+strconv.Itoa(e.UID),
+		"OOM_SAVER_SAFETY_LEVEL="+e.SafetyLevel,
+		"OOM_SAVER_REASON="+e.Reason,
+		"OOM_SAVER_SIGNAL="+e.Signal,
+		"OOM_SAVER_OOM_SCORE="+strconv.Itoa(e.OOMScore),
+		"OOM_SAVER_MEM_TOTAL_MB="+strconv.Itoa(e.MemTotalMB),
+		"OOM_SAVER_MEM_AVAILABLE_MB="+strconv.Itoa(e.MemAvailableMB),
+		"OOM_SAVER_KILL_ERROR="+e.KillError,
+	)
+}
+
+// VetoError is returned by RunPreKill when a hook vetoes the kill.
+type VetoError struct {
+	Hook   string
+	Output string
+}
+
+func (e *VetoError) Error() string {
+	if e.Output != "" {
+		return fmt.Sprintf("kill vetoed by hook %s: %s", e.Hook, e.Output)
+	}
+	return fmt.Sprintf("kill vetoed by hook %s", e.Hook)
+}
+
+// Validate checks the hooks and fills in defaults.
+func (c *Config) Validate() error {
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	for _, list := range [][]Hook{c.PreKill, c.PostKill} {
+		for i := range list {
+			hook := &list[i]
+			if len(hook.Command) == 0 {
+				return fmt.Errorf("hook %d has no command", i+1)
+			}
+			if hook.Name == "" {
+				hook.Name = hook.Command[0]
+			}
+			if hook.Timeout == 0 {
+				hook.Timeout = c.Timeout
+			}
+		}
+	}
+	for _, hook := range c.PostKill {
+		if hook.Veto {
+			return fmt.Errorf("post-kill hook %s cannot veto", hook.Name)
+		}
+	}
+	return nil
+}
+
+// IsEmpty reports whether no hooks are configured.
+func (c Config) IsEmpty() bool {
+	return len(c.PreKill) == 0 && len(c.PostKill) == 0
+}
+
+// RunPreKill runs the pre-kill hooks in order. It stops and returns a
+// *VetoError as soon as a vetoing hook exits non-zero; failures of other
+// hooks are returned alongside but do not stop the kill.
+func (c Config) RunPreKill(ev Event) (veto error, errs []error) {
+	ev.Phase = "pre_kill"
+	for _, hook := range c.PreKill {
+		output, err := run(hook, ev)
+		if err == nil {
+			continue
+		}
+
+		var exitErr *exec.ExitError
+		if hook.Veto && errors.As(err, &exitErr) {
+			return &VetoError{Hook: hook.Name, Output: output}, errs
+		}
+		errs = append(errs, fmt.Errorf("pre-kill hook %s: %w", hook.Name, err))
+	}
+	return nil, errs
+}
+
+// RunPostKill runs every post-kill hook and returns their failures.
+func (c Config) RunPostKill(ev Event) []error {
+	ev.Phase = "post_kill"
+	var errs []error
+	for _, hook := range c.PostKill {
+		if _, err := run(hook, ev); err != nil {
+			errs = append(errs, fmt.Errorf("post-kill hook %s: %w", hook.Name, err))
+		}
+	}
+	return errs
+}
+
+func run(hook Hook, ev Event) (string, error) {
+	input, err := json.Marshal(ev)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hook.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...)
+	cmd.Env = ev.environ()
+	cmd.Stdin = bytes.NewReader(input)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return output.String(), fmt.Errorf("timed out after %s", hook.Timeout)
+	}
+	return string(bytes.TrimSpace(output.Bytes())), err
+}
@@ -0,0 +1,198 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+	"sakthiRathinam/oom-saver/pkg/freezer"
+	"sakthiRathinam/oom-saver/pkg/hooks"
+	"sakthiRathinam/oom-saver/pkg/process"
+	"sakthiRathinam/oom-saver/pkg/reclaim"
+)
+
+// DefaultPath is where the policy file is looked up when --policy is not set.
+const DefaultPath = "/etc/oom-saver/policy.yaml"
+
+// Policy is the site-specific configuration loaded from a YAML file.
+type Policy struct {
+	Classification Classification `yaml:"classification"`
+
+	// OOMScoreAdj rules are applied by the adjust command and re-applied by
+	// the monitor on every tick.
+	OOMScoreAdj []process.OOMScoreAdjRule `yaml:"oom_score_adj"`
+
+	Freeze Freeze `yaml:"freeze"`
+
+	// Reclaim configures the gentler actions tried before killing.
+	Reclaim reclaim.Config `yaml:"reclaim"`
+
+	// Hooks run before and after every automated kill.
+	Hooks hooks.Config `yaml:"hooks"`
+}
+
+// Freeze configures pausing processes instead of killing them when memory is
+// low. Frozen processes are thawed once available memory recovers.
+type Freeze struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Method is "cgroup" (cgroup v2 cgroup.freeze, falling back to SIGSTOP)
+	// or "signal" (SIGSTOP/SIGCONT).
+	Method string `yaml:"method"`
+
+	BelowAvailableMB     int `yaml:"below_available_mb"`
+	ThawAboveAvailableMB int `yaml:"thaw_above_available_mb"`
+	MaxPerTick           int `yaml:"max_per_tick"`
+
+	// Match restricts which processes may be frozen; empty means any
+	// process that is neither critical nor important.
+	Match process.Matcher `yaml:"match"`
+}
+
+// Classification configures the classifier chain.
+type Classification struct {
+	Overrides []process.Override `yaml:"overrides"`
+	Rules     []process.Rule     `yaml:"rules"`
+
+	// ProtectedUnits lists systemd units (shell patterns) whose processes
+	// are always critical.
+	ProtectedUnits []string `yaml:"protected_units"`
+}
+
+// Load reads and validates the policy at path.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy %s: %w", path, err)
+	}
+
+	p := &Policy{}
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+
+	return p, nil
+}
+
+// LoadDefault loads the policy at DefaultPath, returning an empty policy if
+// the file does not exist.
+func LoadDefault() (*Policy, error) {
+	p, err := Load(DefaultPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Policy{}, nil
+	}
+	return p, err
+}
+
+// Validate checks that every rule and override is well formed.
+func (p *Policy) Validate() error {
+	for i := range p.Classification.Rules {
+		rule := &p.Classification.Rules[i]
+		if rule.Name == "" {
+			return fmt.Errorf("classification rule %d has no name", i+1)
+		}
+		if _, err := process.ParseSafetyLevel(string(rule.Level)); err != nil {
+			return fmt.Errorf("classification rule %q: %w", rule.Name, err)
+		}
+		if rule.Match.IsEmpty() {
+			return fmt.Errorf("classification rule %q has no match criteria", rule.Name)
+		}
+		if err := rule.Match.Compile(); err != nil {
+			return fmt.Errorf("classification rule %q: %w", rule.Name, err)
+		}
+	}
+
+	for _, pattern := range p.Classification.ProtectedUnits {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid protected unit pattern %q: %w", pattern, err)
+		}
+	}
+
+	for i, o := range p.Classification.Overrides {
+		if o.PID == 0 && o.Name == "" {
+			return fmt.Errorf("override %d needs a pid or a name", i+1)
+		}
+		if _, err := process.ParseSafetyLevel(string(o.Level)); err != nil {
+			return fmt.Errorf("override %d: %w", i+1, err)
+		}
+	}
+
+	for i := range p.OOMScoreAdj {
+		rule := &p.OOMScoreAdj[i]
+		if rule.Name == "" {
+			return fmt.Errorf("oom_score_adj rule %d has no name", i+1)
+		}
+		if rule.Value < process.MinOOMScoreAdj || rule.Value > process.MaxOOMScoreAdj {
+			return fmt.Errorf("oom_score_adj rule %q: value %d out of range [%d, %d]",
+				rule.Name, rule.Value, process.MinOOMScoreAdj, process.MaxOOMScoreAdj)
+		}
+		if rule.Match.IsEmpty() {
+			return fmt.Errorf("oom_score_adj rule %q has no match criteria", rule.Name)
+		}
+		if err := rule.Match.Compile(); err != nil {
+			return fmt.Errorf("oom_score_adj rule %q: %w", rule.Name, err)
+		}
+	}
+
+	if err := p.Freeze.validate(); err != nil {
+		return err
+	}
+
+	if err := p.Reclaim.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.Hooks.Validate(); err != nil {
+		return fmt.Errorf("hooks: %w", err)
+	}
+
+	return nil
+}
+
+func (f *Freeze) validate() error {
+	if !f.Enabled {
+		return nil
+	}
+	switch f.Method {
+	case "":
+		f.Method = freezer.MethodSignal
+	case freezer.MethodCgroup, freezer.MethodSignal:
+	default:
+		return fmt.Errorf("freeze: invalid method %q (use cgroup or signal)", f.Method)
+	}
+	if f.BelowAvailableMB <= 0 {
+		return fmt.Errorf("freeze: below_available_mb must be positive")
+	}
+	if f.ThawAboveAvailableMB == 0 {
+		f.ThawAboveAvailableMB = f.BelowAvailableMB * 2
+	}
+	if f.ThawAboveAvailableMB < f.BelowAvailableMB {
+		return fmt.Errorf("freeze: thaw_above_available_mb must not be below below_available_mb")
+	}
+	if f.MaxPerTick <= 0 {
+		f.MaxPerTick = 1
+	}
+	if err := f.Match.Compile(); err != nil {
+		return fmt.Errorf("freeze: %w", err)
+	}
+	return nil
+}
+
+// Classifier builds the classifier chain described by the policy: user
+// overrides first, then policy rules, systemd units, Kubernetes pods, then
+// the built-in heuristics.
+func (p *Policy) Classifier() process.Classifier {
+	return process.Chain{
+		process.OverrideClassifier{Overrides: p.Classification.Overrides},
+		process.RuleClassifier{Rules: p.Classification.Rules},
+		process.SystemdClassifier{ProtectedUnits: p.Classification.ProtectedUnits},
+		process.ContainerClassifier{},
+		process.BuiltinClassifier{},
+	}
+}
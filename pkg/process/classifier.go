@@ -1,6 +1,7 @@
 package process
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -24,72 +25,223 @@ var browserProcessNames = []string{
 	"palemoon", "waterfox", "seamonkey", "google-chrome",
 }
 
-func ClassifyProcess(p *Process) string {
-	if p.Status == "zombie" {
-		return "safe"
-	}
+// SafetyLevel describes how safe it is to kill a process.
+type SafetyLevel string
 
-	if p.PID == 1 {
-		return "critical"
-	}
+const (
+	SafetyCritical  SafetyLevel = "critical"
+	SafetyImportant SafetyLevel = "important"
+	SafetySafe      SafetyLevel = "safe"
+	SafetyUnknown   SafetyLevel = "unknown"
+)
 
-	if isKernelThread(p.Name) {
-		return "critical"
-	}
+// SafetyLevels lists every level from most to least protected.
+var SafetyLevels = []SafetyLevel{SafetyCritical, SafetyImportant, SafetySafe, SafetyUnknown}
 
-	if p.OOMScore < -500 {
-		return "critical"
+// ParseSafetyLevel converts a user-supplied string into a SafetyLevel.
+func ParseSafetyLevel(s string) (SafetyLevel, error) {
+	for _, level := range SafetyLevels {
+		if strings.EqualFold(s, string(level)) {
+			return level, nil
+		}
 	}
+	return "", fmt.Errorf("invalid safety level %q (use critical, important, safe or unknown)", s)
+}
 
-	if isCriticalProcessName(p.Name) {
-		return "critical"
+// RuleResult records the outcome of a single classification rule.
+type RuleResult struct {
+	Rule    string      `json:"rule"`
+	Matched bool        `json:"matched"`
+	Level   SafetyLevel `json:"level"`
+	Detail  string      `json:"detail"`
+}
+
+// Classification is the result of classifying a process: the assigned safety
+// level and the ordered list of rules that were evaluated to reach it.
+type Classification struct {
+	Level SafetyLevel  `json:"level"`
+	Rules []RuleResult `json:"rules"`
+}
+
+// MatchedRule returns the rule that decided the classification.
+func (c Classification) MatchedRule() RuleResult {
+	for _, r := range c.Rules {
+		if r.Matched {
+			return r
+		}
 	}
+	return RuleResult{Rule: "default", Level: c.Level}
+}
 
-	if isImportantProcessName(p.Name) {
-		return "important"
+// record appends a rule result and, if it matched, sets the level.
+func (c *Classification) record(rule string, matched bool, level SafetyLevel, detail string) bool {
+	c.Rules = append(c.Rules, RuleResult{
+		Rule:    rule,
+		Matched: matched,
+		Level:   level,
+		Detail:  detail,
+	})
+	if matched {
+		c.Level = level
 	}
+	return matched
+}
 
-	if p.UID >= 1000 {
-		return "safe"
+// Classifier assigns a safety level to a process. Classify appends every rule
+// it evaluated to c and reports whether one of them decided the level.
+type Classifier interface {
+	Classify(p *Process, c *Classification) bool
+}
+
+// Chain runs classifiers in order and stops at the first one that decides.
+type Chain []Classifier
+
+func (ch Chain) Classify(p *Process, c *Classification) bool {
+	for _, classifier := range ch {
+		if classifier != nil && classifier.Classify(p, c) {
+			return true
+		}
 	}
+	return false
+}
+
+// DefaultClassifier is used by ClassifyProcess. Replace it (or wrap it in a
+// Chain) to plug in site-specific logic.
+var DefaultClassifier Classifier = Chain{SystemdClassifier{}, ContainerClassifier{}, BuiltinClassifier{}}
+
+type builtinRule struct {
+	name  string
+	level SafetyLevel
+	match func(p *Process) (bool, string)
+}
+
+var builtinRules = []builtinRule{
+	{"zombie", SafetySafe, func(p *Process) (bool, string) {
+		return p.Status == "zombie", "Zombie process (already dead)"
+	}},
+	{"pid-1", SafetyCritical, func(p *Process) (bool, string) {
+		return p.PID == 1, "PID 1 (init/systemd) - system manager"
+	}},
+	{"kernel-thread", SafetyCritical, func(p *Process) (bool, string) {
+		return isKernelThread(p), "Kernel thread"
+	}},
+	{"oom-score-protected", SafetyCritical, func(p *Process) (bool, string) {
+		return p.OOMScore < -500, fmt.Sprintf("Very negative OOM score (%d) - kernel protected", p.OOMScore)
+	}},
+	{"critical-name", SafetyCritical, func(p *Process) (bool, string) {
+		return isCriticalProcessName(p), fmt.Sprintf("Program %q matches essential system service list", baseName(p))
+	}},
+	{"important-name", SafetyImportant, func(p *Process) (bool, string) {
+		return isImportantProcessName(p), fmt.Sprintf("Program %q matches system daemon list", baseName(p))
+	}},
+	{"user-owned", SafetySafe, func(p *Process) (bool, string) {
+		return p.UID >= 1000, fmt.Sprintf("Owned by regular user (UID %d)", p.UID)
+	}},
+	{"high-oom-score", SafetySafe, func(p *Process) (bool, string) {
+		return p.OOMScore > 300, fmt.Sprintf("High OOM score (%d) - kernel considers killable", p.OOMScore)
+	}},
+	{"root-systemd-child", SafetyImportant, func(p *Process) (bool, string) {
+		return p.UID == 0 && p.PPID == 1, "Owned by root and child of systemd"
+	}},
+	{"system-service", SafetyImportant, func(p *Process) (bool, string) {
+		return p.Slice == "system" && strings.HasSuffix(p.Unit, ".service") && !p.Container.InContainer(),
+			fmt.Sprintf("Runs in system service %s", p.Unit)
+	}},
+}
+
+// BuiltinClassifier applies the hardcoded name lists and heuristics.
+type BuiltinClassifier struct{}
 
-	if p.OOMScore > 300 {
-		return "safe"
+func (BuiltinClassifier) Classify(p *Process, c *Classification) bool {
+	for _, rule := range builtinRules {
+		matched, detail := rule.match(p)
+		if c.record(rule.name, matched, rule.level, detail) {
+			return true
+		}
 	}
+	return false
+}
 
-	if p.UID == 0 && p.PPID == 1 {
-		return "important"
+// ClassifyProcess runs DefaultClassifier and returns the level of the first
+// matching rule together with the trace of every rule evaluated up to that
+// point. Processes no rule matches are unknown.
+func ClassifyProcess(p *Process) Classification {
+	var c Classification
+
+	if !DefaultClassifier.Classify(p, &c) {
+		c.record("default", true, SafetyUnknown, "No rule matched")
 	}
+	return c
+}
 
-	return "unknown"
+// isKernelThread reports whether p is kthreadd or one of its children.
+func isKernelThread(p *Process) bool {
+	return p.PID == 2 || p.PPID == 2
 }
 
-func isKernelThread(name string) bool {
-	return strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]")
+// systemBinaryDirs are the directories system services are installed in. A
+// binary elsewhere cannot claim a protected name just by being called "sshd".
+var systemBinaryDirs = []string{
+	"/usr/bin/", "/usr/sbin/", "/bin/", "/sbin/",
+	"/usr/lib/", "/usr/libexec/", "/lib/", "/usr/local/bin/", "/usr/local/sbin/",
 }
 
-func isCriticalProcessName(name string) bool {
-	for _, critical := range criticalProcessNames {
-		if name == critical || strings.HasPrefix(name, critical) {
+// baseName returns the name used for matching against the built-in lists: the
+// executable's file name when known, the comm name otherwise.
+func baseName(p *Process) string {
+	if p.Exe != "" {
+		return filepath.Base(p.Exe)
+	}
+	return p.Name
+}
+
+func isSystemBinary(exe string) bool {
+	for _, dir := range systemBinaryDirs {
+		if strings.HasPrefix(exe, dir) {
 			return true
 		}
 	}
 	return false
 }
 
-func isImportantProcessName(name string) bool {
-	for _, important := range importantProcessNames {
-		if name == important || strings.HasPrefix(name, important) {
+// matchesNameList reports whether p is one of the named programs. Names are
+// compared exactly, against the executable when it can be read (which must
+// then live in a system directory) or the comm name otherwise, allowing for
+// the kernel's 15 character truncation of comm.
+func matchesNameList(p *Process, names []string) bool {
+	if p.Exe != "" && !isSystemBinary(p.Exe) {
+		return false
+	}
+
+	name := baseName(p)
+	for _, candidate := range names {
+		if name == candidate {
+			return true
+		}
+		if p.Exe == "" && len(candidate) > maxCommLen && name == candidate[:maxCommLen] {
 			return true
 		}
 	}
 	return false
 }
 
-func IsBrowserProcess(name string) bool {
-	lowerName := strings.ToLower(name)
+// maxCommLen is the longest name the kernel stores in /proc/<pid>/comm.
+const maxCommLen = 15
+
+func isCriticalProcessName(p *Process) bool {
+	return matchesNameList(p, criticalProcessNames)
+}
+
+func isImportantProcessName(p *Process) bool {
+	return matchesNameList(p, importantProcessNames)
+}
+
+// IsBrowserProcess reports whether p is a web browser, judged by its
+// executable's name (or comm name when the executable is unreadable).
+func IsBrowserProcess(p *Process) bool {
+	name := strings.ToLower(baseName(p))
 	for _, browser := range browserProcessNames {
-		if lowerName == browser || strings.Contains(lowerName, browser) {
+		if strings.HasPrefix(name, browser) {
 			return true
 		}
 	}
@@ -173,3 +325,20 @@ func readProcessOOMScoreAdj(pid int) (int, error) {
 
 	return scoreAdj, nil
 }
+
+func readProcessExe(pid int) (string, error) {
+	exe, err := os.Readlink(filepath.Join("/proc", strconv.Itoa(pid), "exe"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(exe, " (deleted)"), nil
+}
+
+func readProcessCmdline(pid int) (string, error) {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return "", err
+	}
+	args := strings.Split(strings.TrimRight(string(data), "\x00"), "\x00")
+	return strings.TrimSpace(strings.Join(args, " ")), nil
+}
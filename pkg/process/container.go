@@ -0,0 +1,204 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kubernetes QoS classes.
+const (
+	QoSGuaranteed = "Guaranteed"
+	QoSBurstable  = "Burstable"
+	QoSBestEffort = "BestEffort"
+)
+
+// ContainerInfo describes the container a process runs in, if any.
+type ContainerInfo struct {
+	Runtime  string
+	ID       string
+	PodUID   string
+	QoSClass string
+
+	// Namespaced is true when the process lives in a different PID namespace
+	// than init, even if no container ID could be found in its cgroup.
+	Namespaced bool
+}
+
+// InContainer reports whether the process runs in a container.
+func (ci ContainerInfo) InContainer() bool {
+	return ci.ID != "" || ci.PodUID != "" || ci.Namespaced
+}
+
+// String returns a short human-readable description, or "host".
+func (ci ContainerInfo) String() string {
+	var parts []string
+	if ci.PodUID != "" {
+		parts = append(parts, fmt.Sprintf("pod %s (%s)", shortID(ci.PodUID, 8), ci.QoSClass))
+	}
+	if ci.ID != "" {
+		parts = append(parts, fmt.Sprintf("%s %s", ci.Runtime, shortID(ci.ID, 12)))
+	}
+	if len(parts) == 0 {
+		if ci.Namespaced {
+			return "namespaced"
+		}
+		return "host"
+	}
+	return strings.Join(parts, " / ")
+}
+
+func shortID(id string, n int) string {
+	if len(id) > n {
+		return id[:n]
+	}
+	return id
+}
+
+var (
+	// docker-<id>.scope, cri-containerd-<id>.scope, crio-<id>.scope, libpod-<id>.scope
+	scopeContainerRe = regexp.MustCompile(`^(docker|cri-containerd|crio|libpod)-([0-9a-f]{12,64})\.scope$`)
+	// cgroupfs driver: a bare 64 character container ID
+	bareContainerRe = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	// kubepods-burstable-pod<uid>.slice, kubelet-kubepods-pod<uid>.slice (kind)
+	systemdPodRe = regexp.MustCompile(`kubepods(?:-(besteffort|burstable))?-pod([0-9a-f_-]+)\.slice$`)
+	// cgroupfs driver: pod<uid>
+	cgroupfsPodRe = regexp.MustCompile(`^pod([0-9a-f-]{36})$`)
+)
+
+var runtimeNames = map[string]string{
+	"docker":         "docker",
+	"cri-containerd": "containerd",
+	"crio":           "cri-o",
+	"libpod":         "podman",
+}
+
+// containerFromCgroup extracts container and pod information from a cgroup
+// path. When containers are nested (e.g. kind nodes running pods), the
+// innermost container wins.
+func containerFromCgroup(cgroup string) ContainerInfo {
+	var ci ContainerInfo
+	components := strings.Split(strings.Trim(cgroup, "/"), "/")
+
+	for i, component := range components {
+		if m := scopeContainerRe.FindStringSubmatch(component); m != nil {
+			ci.Runtime = runtimeNames[m[1]]
+			ci.ID = m[2]
+			continue
+		}
+		if bareContainerRe.MatchString(component) {
+			ci.Runtime = runtimeFromAncestors(components[:i])
+			ci.ID = component
+			continue
+		}
+		if m := systemdPodRe.FindStringSubmatch(component); m != nil {
+			ci.PodUID = strings.ReplaceAll(m[2], "_", "-")
+			ci.QoSClass = qosClass(m[1])
+			continue
+		}
+		if m := cgroupfsPodRe.FindStringSubmatch(component); m != nil && i > 0 {
+			ci.PodUID = m[1]
+			ci.QoSClass = QoSGuaranteed
+			if components[i-1] == "besteffort" || components[i-1] == "burstable" {
+				ci.QoSClass = qosClass(components[i-1])
+			}
+		}
+	}
+
+	return ci
+}
+
+func qosClass(tier string) string {
+	switch tier {
+	case "besteffort":
+		return QoSBestEffort
+	case "burstable":
+		return QoSBurstable
+	default:
+		return QoSGuaranteed
+	}
+}
+
+func runtimeFromAncestors(components []string) string {
+	for i := len(components) - 1; i >= 0; i-- {
+		switch {
+		case components[i] == "docker":
+			return "docker"
+		case strings.HasPrefix(components[i], "kubepods"):
+			return "kubernetes"
+		}
+	}
+	return "container"
+}
+
+// readNamespace returns the inode identifier of one of the process's
+// namespaces, e.g. "pid:[4026531836]".
+func readNamespace(pid int, ns string) (string, error) {
+	return os.Readlink(filepath.Join("/proc", strconv.Itoa(pid), "ns", ns))
+}
+
+// hostPIDNamespace is init's PID namespace, read once per scan.
+func hostPIDNamespace() string {
+	ns, _ := readNamespace(1, "pid")
+	return ns
+}
+
+// readProcessContainer combines the cgroup path and namespaces of a process
+// into its container information.
+func readProcessContainer(pid int, cgroup string, hostPIDNS string) ContainerInfo {
+	ci := containerFromCgroup(cgroup)
+	if hostPIDNS != "" {
+		if ns, err := readNamespace(pid, "pid"); err == nil && ns != hostPIDNS {
+			ci.Namespaced = true
+		}
+	}
+	return ci
+}
+
+// ContainerClassifier classifies Kubernetes pods by QoS class: Guaranteed
+// pods are important, BestEffort pods are preferred victims. Burstable pods
+// and plain containers are left to the rest of the chain.
+type ContainerClassifier struct{}
+
+func (ContainerClassifier) Classify(p *Process, c *Classification) bool {
+	if p.Container.PodUID == "" {
+		return false
+	}
+
+	switch p.Container.QoSClass {
+	case QoSGuaranteed:
+		return c.record("pod-guaranteed", true, SafetyImportant, fmt.Sprintf("Kubernetes pod %s has Guaranteed QoS", p.Container.PodUID))
+	case QoSBestEffort:
+		return c.record("pod-besteffort", true, SafetySafe, fmt.Sprintf("Kubernetes pod %s has BestEffort QoS", p.Container.PodUID))
+	}
+
+	c.record("pod-burstable", false, SafetyUnknown, fmt.Sprintf("Kubernetes pod %s has Burstable QoS", p.Container.PodUID))
+	return false
+}
+
+// GroupByContainer splits processes by container, returning the group
+// labels sorted with the host first.
+func GroupByContainer(processes []Process) ([]string, map[string][]Process) {
+	groups := make(map[string][]Process)
+	for _, p := range processes {
+		label := p.Container.String()
+		groups[label] = append(groups[label], p)
+	}
+
+	labels := make([]string, 0, len(groups))
+	for label := range groups {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i] == "host" || labels[j] == "host" {
+			return labels[i] == "host"
+		}
+		return labels[i] < labels[j]
+	})
+
+	return labels, groups
+}
@@ -0,0 +1,74 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Bounds of /proc/<pid>/oom_score_adj.
+const (
+	MinOOMScoreAdj = -1000
+	MaxOOMScoreAdj = 1000
+)
+
+// OOMScoreAdjRule sets oom_score_adj for every process matching its criteria.
+type OOMScoreAdjRule struct {
+	Name  string  `yaml:"name" json:"name"`
+	Value int     `yaml:"value" json:"value"`
+	Match Matcher `yaml:"match" json:"match"`
+}
+
+// AdjustResult records a change made (or planned) by ApplyOOMScoreAdj.
+type AdjustResult struct {
+	Process  Process
+	Rule     string
+	OldValue int
+	NewValue int
+	Err      error
+}
+
+// SetOOMScoreAdj writes value to /proc/<pid>/oom_score_adj. Lowering the value
+// below its current setting requires CAP_SYS_RESOURCE.
+func SetOOMScoreAdj(pid int, value int) error {
+	if value < MinOOMScoreAdj || value > MaxOOMScoreAdj {
+		return fmt.Errorf("oom_score_adj %d out of range [%d, %d]", value, MinOOMScoreAdj, MaxOOMScoreAdj)
+	}
+
+	path := filepath.Join("/proc", strconv.Itoa(pid), "oom_score_adj")
+	if err := os.WriteFile(path, []byte(strconv.Itoa(value)), 0o644); err != nil {
+		return fmt.Errorf("failed to set oom_score_adj of PID %d: %w", pid, err)
+	}
+	return nil
+}
+
+// ApplyOOMScoreAdj sets oom_score_adj on every process matched by a rule
+// whose current value differs, so it is cheap to call on every monitor tick
+// and picks up new processes as they appear. The first matching rule wins.
+// With dryRun set, nothing is written.
+func ApplyOOMScoreAdj(processes []Process, rules []OOMScoreAdjRule, dryRun bool) []AdjustResult {
+	var results []AdjustResult
+
+	for _, proc := range processes {
+		if proc.Status == "zombie" {
+			continue
+		}
+
+		for _, rule := range rules {
+			if !rule.Match.Matches(&proc) {
+				continue
+			}
+			if proc.OOMScoreAdj != rule.Value {
+				result := AdjustResult{Process: proc, Rule: rule.Name, OldValue: proc.OOMScoreAdj, NewValue: rule.Value}
+				if !dryRun {
+					result.Err = SetOOMScoreAdj(proc.PID, rule.Value)
+				}
+				results = append(results, result)
+			}
+			break
+		}
+	}
+
+	return results
+}
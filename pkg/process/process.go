@@ -13,21 +13,45 @@ import (
 
 type Process struct {
 	Name        string
+	Exe         string
+	Cmdline     string
 	PID         int
 	Status      string
-	SafetyLevel string
+	SafetyLevel SafetyLevel
 	UID         int
 	PPID        int
 	OOMScore    int
+	OOMScoreAdj int
+	Cgroup      string
+	Unit        string
+	Slice       string
+	Container   ContainerInfo
+
+	Classification Classification
 }
 
 type CleanupConfig struct {
-	KillUserProcesses bool
-	KillBrowsers      bool
-	KillSafeLevel     bool
+	KillUserProcesses  bool
+	KillBrowsers       bool
+	KillSafeLevel      bool
 	KillImportantLevel bool
-	MinOOMScore       int
-	KillZombiesOnly   bool
+	MinOOMScore        int
+	KillZombiesOnly    bool
+
+	// PreKill, if set, is called before a process is signalled. Returning an
+	// error vetoes the kill and keeps the process.
+	PreKill func(p Process, reason string) error
+
+	// OnKill, if set, is called for every process a signal was sent to.
+	OnKill func(KillEvent)
+}
+
+// KillEvent describes a signal sent by one of the automated cleanup functions.
+type KillEvent struct {
+	Process Process
+	Reason  string
+	Signal  syscall.Signal
+	Err     error
 }
 
 func GetAllRunningProcesses() ([]Process, error) {
@@ -51,6 +75,8 @@ func getAllRunningProcessesFromLinux() ([]Process, error) {
 		return processes, fmt.Errorf("failed to read /proc directory: %w", err)
 	}
 
+	hostPIDNS := hostPIDNamespace()
+
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -79,17 +105,30 @@ func getAllRunningProcessesFromLinux() ([]Process, error) {
 		uid, _ := readProcessUID(pid)
 		ppid, _ := readProcessPPID(pid)
 		oomScore, _ := readProcessOOMScore(pid)
+		oomScoreAdj, _ := readProcessOOMScoreAdj(pid)
+		exe, _ := readProcessExe(pid)
+		cmdline, _ := readProcessCmdline(pid)
+		cgroup, _ := readProcessCgroup(pid)
+		unit, slice := unitFromCgroup(cgroup)
 
 		process := Process{
-			Name:     processName,
-			PID:      pid,
-			Status:   processState,
-			UID:      uid,
-			PPID:     ppid,
-			OOMScore: oomScore,
+			Name:        processName,
+			Exe:         exe,
+			Cmdline:     cmdline,
+			PID:         pid,
+			Status:      processState,
+			UID:         uid,
+			PPID:        ppid,
+			OOMScore:    oomScore,
+			OOMScoreAdj: oomScoreAdj,
+			Cgroup:      cgroup,
+			Unit:        unit,
+			Slice:       slice,
+			Container:   readProcessContainer(pid, cgroup, hostPIDNS),
 		}
 
-		process.SafetyLevel = ClassifyProcess(&process)
+		process.Classification = ClassifyProcess(&process)
+		process.SafetyLevel = process.Classification.Level
 
 		processes = append(processes, process)
 	}
@@ -138,12 +177,12 @@ func parseProcessState(statusContent string) string {
 	return "unknown"
 }
 
-func KillProcessIfZombie(processes []Process, killAll bool) ([]Process, error) {
+func KillProcessIfZombie(processes []Process, killAll bool, onKill func(KillEvent)) ([]Process, error) {
 	var activeProcesses []Process
 
 	for _, proc := range processes {
 		if proc.Status == "zombie" {
-			shouldKill := killAll || proc.SafetyLevel == "safe"
+			shouldKill := killAll || proc.SafetyLevel == SafetySafe
 
 			if shouldKill {
 				fmt.Printf("Found zombie process: PID %d (%s) [%s], sending SIGTERM...\n", proc.PID, proc.Name, proc.SafetyLevel)
@@ -151,6 +190,9 @@ func KillProcessIfZombie(processes []Process, killAll bool) ([]Process, error) {
 				if err != nil {
 					fmt.Printf("  Warning: failed to send signal to PID %d: %v\n", proc.PID, err)
 				}
+				if onKill != nil {
+					onKill(KillEvent{Process: proc, Reason: "zombie", Signal: syscall.SIGTERM, Err: err})
+				}
 			} else {
 				fmt.Printf("Skipping %s zombie: PID %d (%s) - use --auto-kill-all-zombies to kill\n", proc.SafetyLevel, proc.PID, proc.Name)
 				activeProcesses = append(activeProcesses, proc)
@@ -171,7 +213,7 @@ func KillProcessWithConfig(processes []Process, config CleanupConfig) ([]Process
 		reason := ""
 
 		// Skip critical processes always
-		if proc.SafetyLevel == "critical" {
+		if proc.SafetyLevel == SafetyCritical {
 			activeProcesses = append(activeProcesses, proc)
 			continue
 		}
@@ -188,17 +230,17 @@ func KillProcessWithConfig(processes []Process, config CleanupConfig) ([]Process
 			reason = "user process"
 		}
 
-		if config.KillBrowsers && IsBrowserProcess(proc.Name) {
+		if config.KillBrowsers && IsBrowserProcess(&proc) {
 			shouldKill = true
 			reason = "browser process"
 		}
 
-		if config.KillSafeLevel && proc.SafetyLevel == "safe" {
+		if config.KillSafeLevel && proc.SafetyLevel == SafetySafe {
 			shouldKill = true
 			reason = "safe level"
 		}
 
-		if config.KillImportantLevel && proc.SafetyLevel == "important" {
+		if config.KillImportantLevel && proc.SafetyLevel == SafetyImportant {
 			shouldKill = true
 			reason = "important level"
 		}
@@ -210,12 +252,23 @@ func KillProcessWithConfig(processes []Process, config CleanupConfig) ([]Process
 
 		// Only kill zombies or problematic processes
 		if shouldKill && (proc.Status == "zombie" || proc.OOMScore > 500) {
+			if config.PreKill != nil {
+				if err := config.PreKill(proc, reason); err != nil {
+					fmt.Printf("Not killing PID %d (%s): %v\n", proc.PID, proc.Name, err)
+					activeProcesses = append(activeProcesses, proc)
+					continue
+				}
+			}
+
 			fmt.Printf("Killing process: PID %d (%s) [%s] - %s\n", proc.PID, proc.Name, proc.SafetyLevel, reason)
 			err := syscall.Kill(proc.PID, syscall.SIGTERM)
 			if err != nil {
 				fmt.Printf("  Warning: failed to send signal to PID %d: %v\n", proc.PID, err)
 				activeProcesses = append(activeProcesses, proc)
 			}
+			if config.OnKill != nil {
+				config.OnKill(KillEvent{Process: proc, Reason: reason, Signal: syscall.SIGTERM, Err: err})
+			}
 		} else {
 			activeProcesses = append(activeProcesses, proc)
 		}
@@ -246,7 +299,7 @@ func KillProcessWithSafety(pid int, signal syscall.Signal, force bool) error {
 		return fmt.Errorf("process %d not found", pid)
 	}
 
-	if targetProcess.SafetyLevel == "critical" && !force {
+	if targetProcess.SafetyLevel == SafetyCritical && !force {
 		return fmt.Errorf("cannot kill critical process (PID %d, %s) without --force flag", pid, targetProcess.Name)
 	}
 
@@ -0,0 +1,158 @@
+package process
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Matcher selects processes by their attributes. Every non-empty field must
+// match; an empty Matcher matches nothing.
+type Matcher struct {
+	Names   []string `yaml:"names,omitempty" json:"names,omitempty"`
+	Exes    []string `yaml:"exes,omitempty" json:"exes,omitempty"`
+	Cmdline string   `yaml:"cmdline,omitempty" json:"cmdline,omitempty"`
+	UIDs    []int    `yaml:"uids,omitempty" json:"uids,omitempty"`
+
+	cmdlineRe *regexp.Regexp
+}
+
+// Compile validates and compiles the cmdline pattern. Matches compiles it on
+// first use if Compile was not called, treating an invalid pattern as no match.
+func (m *Matcher) Compile() error {
+	if m.Cmdline == "" {
+		return nil
+	}
+	re, err := regexp.Compile(m.Cmdline)
+	if err != nil {
+		return fmt.Errorf("invalid cmdline pattern %q: %w", m.Cmdline, err)
+	}
+	m.cmdlineRe = re
+	return nil
+}
+
+// IsEmpty reports whether the matcher has no criteria.
+func (m Matcher) IsEmpty() bool {
+	return len(m.Names) == 0 && len(m.Exes) == 0 && m.Cmdline == "" && len(m.UIDs) == 0
+}
+
+// Matches reports whether p satisfies every criterion of the matcher. Names
+// are compared exactly against the comm name, exes exactly against the
+// resolved executable path.
+func (m Matcher) Matches(p *Process) bool {
+	if m.IsEmpty() {
+		return false
+	}
+	if len(m.Names) > 0 && !containsString(m.Names, p.Name) {
+		return false
+	}
+	if len(m.Exes) > 0 && (p.Exe == "" || !containsString(m.Exes, p.Exe)) {
+		return false
+	}
+	if m.Cmdline != "" {
+		if m.cmdlineRe == nil && m.Compile() != nil {
+			return false
+		}
+		if !m.cmdlineRe.MatchString(p.Cmdline) {
+			return false
+		}
+	}
+	if len(m.UIDs) > 0 && !containsInt(m.UIDs, p.UID) {
+		return false
+	}
+	return true
+}
+
+// String describes the matcher's criteria for classification traces.
+func (m Matcher) String() string {
+	var parts []string
+	if len(m.Names) > 0 {
+		parts = append(parts, "name in "+strings.Join(m.Names, ","))
+	}
+	if len(m.Exes) > 0 {
+		parts = append(parts, "exe in "+strings.Join(m.Exes, ","))
+	}
+	if m.Cmdline != "" {
+		parts = append(parts, fmt.Sprintf("cmdline =~ /%s/", m.Cmdline))
+	}
+	if len(m.UIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("uid in %v", m.UIDs))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// Rule assigns a safety level to processes matching its criteria.
+type Rule struct {
+	Name  string      `yaml:"name" json:"name"`
+	Level SafetyLevel `yaml:"level" json:"level"`
+	Match Matcher     `yaml:"match" json:"match"`
+}
+
+// RuleClassifier applies site-specific rules, typically loaded from the
+// policy file. The first matching rule wins.
+type RuleClassifier struct {
+	Rules []Rule
+}
+
+func (rc RuleClassifier) Classify(p *Process, c *Classification) bool {
+	for _, rule := range rc.Rules {
+		detail := fmt.Sprintf("Policy rule %q: %s", rule.Name, rule.Match)
+		if c.record("rule:"+rule.Name, rule.Match.Matches(p), rule.Level, detail) {
+			return true
+		}
+	}
+	return false
+}
+
+// Override pins the safety level of a single process, by PID or by name.
+type Override struct {
+	PID   int         `yaml:"pid,omitempty" json:"pid,omitempty"`
+	Name  string      `yaml:"name,omitempty" json:"name,omitempty"`
+	Level SafetyLevel `yaml:"level" json:"level"`
+}
+
+// Matches reports whether the override applies to p.
+func (o Override) Matches(p *Process) bool {
+	if o.PID != 0 {
+		return o.PID == p.PID
+	}
+	return o.Name != "" && o.Name == p.Name
+}
+
+// OverrideClassifier applies user overrides. It runs before every other
+// classifier so users always have the last word.
+type OverrideClassifier struct {
+	Overrides []Override
+}
+
+func (oc OverrideClassifier) Classify(p *Process, c *Classification) bool {
+	for _, o := range oc.Overrides {
+		if !o.Matches(p) {
+			continue
+		}
+		target := o.Name
+		if o.PID != 0 {
+			target = fmt.Sprintf("PID %d", o.PID)
+		}
+		return c.record("override", true, o.Level, fmt.Sprintf("User override for %s", target))
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(list []int, n int) bool {
+	for _, item := range list {
+		if item == n {
+			return true
+		}
+	}
+	return false
+}
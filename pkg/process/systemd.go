@@ -0,0 +1,159 @@
+package process
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// unitSuffixes are the unit types that can own processes.
+var unitSuffixes = []string{".service", ".scope"}
+
+// readProcessCgroup returns the process's path in the systemd cgroup
+// hierarchy: the unified (v2) path, or the name=systemd path on hybrid hosts.
+func readProcessCgroup(pid int) (string, error) {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	return parseCgroup(string(data)), nil
+}
+
+func parseCgroup(content string) string {
+	var unified string
+	for _, line := range strings.Split(content, "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		switch {
+		case parts[1] == "name=systemd":
+			return parts[2]
+		case parts[0] == "0" && parts[1] == "":
+			unified = parts[2]
+		}
+	}
+	return unified
+}
+
+// unitFromCgroup returns the innermost systemd unit in a cgroup path and the
+// top-level slice ("system", "user", "machine", ...) it lives in.
+func unitFromCgroup(cgroup string) (unit string, slice string) {
+	components := strings.Split(strings.Trim(cgroup, "/"), "/")
+	if len(components) > 0 && strings.HasSuffix(components[0], ".slice") {
+		slice = strings.TrimSuffix(components[0], ".slice")
+	}
+
+	for i := len(components) - 1; i >= 0; i-- {
+		for _, suffix := range unitSuffixes {
+			if strings.HasSuffix(components[i], suffix) {
+				return components[i], slice
+			}
+		}
+	}
+	return "", slice
+}
+
+// IsUserUnit reports whether the process's unit is managed by a per-user
+// systemd instance rather than the system manager.
+func (p *Process) IsUserUnit() bool {
+	return strings.Contains(p.Cgroup, "/user@")
+}
+
+// UnitProperties holds the systemd unit settings relevant to OOM handling.
+type UnitProperties struct {
+	OOMPolicy            string
+	ManagedOOMPreference string
+}
+
+// LookupUnitProperties returns the properties of a system unit. It is a
+// variable so tests and library users can avoid shelling out to systemctl.
+var LookupUnitProperties = systemctlUnitProperties
+
+var (
+	unitPropsMu    sync.Mutex
+	unitPropsCache = map[string]UnitProperties{}
+)
+
+func systemctlUnitProperties(unit string) (UnitProperties, error) {
+	unitPropsMu.Lock()
+	defer unitPropsMu.Unlock()
+
+	if props, ok := unitPropsCache[unit]; ok {
+		return props, nil
+	}
+
+	out, err := exec.Command("systemctl", "show", unit, "-p", "OOMPolicy", "-p", "ManagedOOMPreference").Output()
+	if err != nil {
+		return UnitProperties{}, fmt.Errorf("failed to query unit %s: %w", unit, err)
+	}
+
+	var props UnitProperties
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "OOMPolicy":
+			props.OOMPolicy = value
+		case "ManagedOOMPreference":
+			props.ManagedOOMPreference = value
+		}
+	}
+
+	unitPropsCache[unit] = props
+	return props, nil
+}
+
+// SystemdClassifier classifies processes by the systemd unit they run in.
+// Protected units are matched with shell-style patterns (e.g. "postgresql*").
+// Unit properties are only consulted for system units, matching the scope
+// systemd-oomd honours them in.
+type SystemdClassifier struct {
+	ProtectedUnits []string
+}
+
+func (sc SystemdClassifier) Classify(p *Process, c *Classification) bool {
+	if p.Unit == "" {
+		return false
+	}
+
+	for _, pattern := range sc.ProtectedUnits {
+		matched, _ := path.Match(pattern, p.Unit)
+		if c.record("protected-unit", matched, SafetyCritical, fmt.Sprintf("Unit %s is protected by policy (%s)", p.Unit, pattern)) {
+			return true
+		}
+	}
+
+	if p.IsUserUnit() {
+		return false
+	}
+
+	props, err := LookupUnitProperties(p.Unit)
+	if err != nil {
+		return false
+	}
+
+	switch props.ManagedOOMPreference {
+	case "omit":
+		return c.record("unit-oom-omit", true, SafetyCritical, fmt.Sprintf("Unit %s sets ManagedOOMPreference=omit", p.Unit))
+	case "avoid":
+		return c.record("unit-oom-avoid", true, SafetyImportant, fmt.Sprintf("Unit %s sets ManagedOOMPreference=avoid", p.Unit))
+	case "prefer":
+		return c.record("unit-oom-prefer", true, SafetySafe, fmt.Sprintf("Unit %s sets ManagedOOMPreference=prefer", p.Unit))
+	}
+
+	if props.OOMPolicy == "kill" {
+		return c.record("unit-oom-policy-kill", true, SafetyImportant, fmt.Sprintf("Unit %s sets OOMPolicy=kill - killing one process stops the whole unit", p.Unit))
+	}
+
+	return false
+}
@@ -0,0 +1,314 @@
+package reclaim
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"sakthiRathinam/oom-saver/pkg/memory"
+	"sakthiRathinam/oom-saver/pkg/process"
+)
+
+// Step actions, in roughly increasing order of disruption.
+const (
+	ActionCgroupReclaim = "cgroup_reclaim"
+	ActionCgroupHigh    = "cgroup_high"
+	ActionCompactMemory = "compact_memory"
+	ActionDropCaches    = "drop_caches"
+)
+
+const (
+	cgroupRoot        = "/sys/fs/cgroup"
+	compactMemoryPath = "/proc/sys/vm/compact_memory"
+	dropCachesPath    = "/proc/sys/vm/drop_caches"
+)
+
+// Config describes the reclaim ladder. Steps run in order while available
+// memory is below BelowAvailableMB, re-checking memory after each step and
+// stopping as soon as TargetAvailableMB is reached.
+type Config struct {
+	Enabled           bool          `yaml:"enabled"`
+	BelowAvailableMB  int           `yaml:"below_available_mb"`
+	TargetAvailableMB int           `yaml:"target_available_mb"`
+	Settle            time.Duration `yaml:"settle"`
+	Steps             []Step        `yaml:"steps"`
+}
+
+// Step is one rung of the ladder.
+type Step struct {
+	Action string `yaml:"action"`
+
+	// Cgroup targets a specific cgroup for the cgroup_* actions. When empty
+	// the cgroup using the most memory that holds no critical process is used.
+	Cgroup string `yaml:"cgroup"`
+
+	// AmountMB is how much cgroup_reclaim asks the kernel to reclaim.
+	AmountMB int `yaml:"amount_mb"`
+
+	// Ratio is the fraction of current usage cgroup_high sets memory.high to.
+	Ratio float64 `yaml:"ratio"`
+
+	// Mode is the value written to drop_caches: 1 page cache, 2 slab, 3 both.
+	Mode int `yaml:"mode"`
+}
+
+// Validate checks the configuration and fills in defaults.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.BelowAvailableMB <= 0 {
+		return fmt.Errorf("reclaim: below_available_mb must be positive")
+	}
+	if c.TargetAvailableMB == 0 {
+		c.TargetAvailableMB = c.BelowAvailableMB
+	}
+	if c.TargetAvailableMB < c.BelowAvailableMB {
+		return fmt.Errorf("reclaim: target_available_mb must not be below below_available_mb")
+	}
+	if c.Settle == 0 {
+		c.Settle = 2 * time.Second
+	}
+	if len(c.Steps) == 0 {
+		return fmt.Errorf("reclaim: no steps configured")
+	}
+
+	for i := range c.Steps {
+		step := &c.Steps[i]
+		switch step.Action {
+		case ActionCgroupReclaim:
+			if step.AmountMB <= 0 {
+				return fmt.Errorf("reclaim step %d: cgroup_reclaim needs a positive amount_mb", i+1)
+			}
+		case ActionCgroupHigh:
+			if step.Ratio == 0 {
+				step.Ratio = 0.8
+			}
+			if step.Ratio <= 0 || step.Ratio >= 1 {
+				return fmt.Errorf("reclaim step %d: cgroup_high ratio must be between 0 and 1", i+1)
+			}
+		case ActionCompactMemory:
+		case ActionDropCaches:
+			if step.Mode == 0 {
+				step.Mode = 1
+			}
+			if step.Mode < 1 || step.Mode > 3 {
+				return fmt.Errorf("reclaim step %d: drop_caches mode must be 1, 2 or 3", i+1)
+			}
+		default:
+			return fmt.Errorf("reclaim step %d: unknown action %q", i+1, step.Action)
+		}
+	}
+	return nil
+}
+
+// Result records what a step did and the memory available afterwards.
+type Result struct {
+	Step        Step
+	Target      string
+	AvailableMB int
+	Err         error
+}
+
+// String describes the result for log output.
+func (r Result) String() string {
+	desc := r.Step.Action
+	if r.Target != "" {
+		desc += " on " + r.Target
+	}
+	switch r.Step.Action {
+	case ActionCgroupReclaim:
+		desc += fmt.Sprintf(" (%d MB)", r.Step.AmountMB)
+	case ActionCgroupHigh:
+		desc += fmt.Sprintf(" (%.0f%% of usage)", r.Step.Ratio*100)
+	case ActionDropCaches:
+		desc += fmt.Sprintf(" (mode %d)", r.Step.Mode)
+	}
+	return desc
+}
+
+// Ladder runs the configured steps. It remembers the memory.high values it
+// lowered so they can be restored once memory recovers.
+type Ladder struct {
+	Config Config
+
+	// ReadMemory samples system memory; defaults to memory.GetMemoryStats.
+	ReadMemory func() (*memory.MemoryStats, error)
+
+	// Sleep waits for a step to take effect; defaults to time.Sleep.
+	Sleep func(time.Duration)
+
+	lowered map[string]string
+}
+
+// NewLadder returns a ladder for cfg.
+func NewLadder(cfg Config) *Ladder {
+	return &Ladder{
+		Config:     cfg,
+		ReadMemory: memory.GetMemoryStats,
+		Sleep:      time.Sleep,
+		lowered:    make(map[string]string),
+	}
+}
+
+// Run climbs the ladder if available memory is below the threshold. It
+// returns the steps taken and whether memory reached the target, in which
+// case killing can be skipped.
+func (l *Ladder) Run(availableMB int, processes []process.Process) ([]Result, bool) {
+	if availableMB >= l.Config.BelowAvailableMB {
+		return nil, true
+	}
+
+	var results []Result
+	for _, step := range l.Config.Steps {
+		result := l.runStep(step, processes)
+
+		l.Sleep(l.Config.Settle)
+		stats, err := l.ReadMemory()
+		if err != nil {
+			result.Err = fmt.Errorf("failed to re-read memory: %w", err)
+			results = append(results, result)
+			return results, false
+		}
+		result.AvailableMB = stats.AvailableMB
+		results = append(results, result)
+
+		if stats.AvailableMB >= l.Config.TargetAvailableMB {
+			return results, true
+		}
+	}
+	return results, false
+}
+
+// Restore resets every memory.high the ladder lowered. Call it once memory
+// has recovered.
+func (l *Ladder) Restore() []Result {
+	var results []Result
+	for cgroup, original := range l.lowered {
+		result := Result{Step: Step{Action: "restore_memory_high"}, Target: cgroup}
+		result.Err = writeCgroupFile(cgroup, "memory.high", original)
+		results = append(results, result)
+		delete(l.lowered, cgroup)
+	}
+	return results
+}
+
+func (l *Ladder) runStep(step Step, processes []process.Process) Result {
+	result := Result{Step: step}
+
+	switch step.Action {
+	case ActionCgroupReclaim:
+		result.Target = step.Cgroup
+		if result.Target == "" {
+			result.Target = HogCgroup(processes)
+		}
+		if result.Target == "" {
+			result.Err = fmt.Errorf("no cgroup to reclaim from")
+			break
+		}
+		result.Err = writeCgroupFile(result.Target, "memory.reclaim", fmt.Sprintf("%dM", step.AmountMB))
+
+	case ActionCgroupHigh:
+		result.Target = step.Cgroup
+		if result.Target == "" {
+			result.Target = HogCgroup(processes)
+		}
+		if result.Target == "" {
+			result.Err = fmt.Errorf("no cgroup to throttle")
+			break
+		}
+		result.Err = l.lowerMemoryHigh(result.Target, step.Ratio)
+
+	case ActionCompactMemory:
+		result.Err = writeFile(compactMemoryPath, "1")
+
+	case ActionDropCaches:
+		syscall.Sync()
+		result.Err = writeFile(dropCachesPath, strconv.Itoa(step.Mode))
+	}
+
+	return result
+}
+
+func (l *Ladder) lowerMemoryHigh(cgroup string, ratio float64) error {
+	current, err := readCgroupInt(cgroup, "memory.current")
+	if err != nil {
+		return err
+	}
+	original, err := readCgroupFile(cgroup, "memory.high")
+	if err != nil {
+		return err
+	}
+
+	if _, ok := l.lowered[cgroup]; !ok {
+		l.lowered[cgroup] = original
+	}
+	return writeCgroupFile(cgroup, "memory.high", strconv.FormatInt(int64(float64(current)*ratio), 10))
+}
+
+// HogCgroup returns the cgroup, among those of the given processes, with the
+// highest memory.current that contains no critical process.
+func HogCgroup(processes []process.Process) string {
+	protected := make(map[string]bool)
+	for _, p := range processes {
+		if p.SafetyLevel == process.SafetyCritical {
+			protected[p.Cgroup] = true
+		}
+	}
+
+	var (
+		hog     string
+		hogSize int64
+		seen    = make(map[string]bool)
+	)
+	for _, p := range processes {
+		if p.Cgroup == "" || p.Cgroup == "/" || seen[p.Cgroup] || protected[p.Cgroup] {
+			continue
+		}
+		seen[p.Cgroup] = true
+
+		size, err := readCgroupInt(p.Cgroup, "memory.current")
+		if err != nil {
+			continue
+		}
+		if size > hogSize {
+			hog, hogSize = p.Cgroup, size
+		}
+	}
+	return hog
+}
+
+func readCgroupFile(cgroup string, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(cgroupRoot, cgroup, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s of %s: %w", name, cgroup, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readCgroupInt(cgroup string, name string) (int64, error) {
+	value, err := readCgroupFile(cgroup, name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s of %s: %w", name, cgroup, err)
+	}
+	return n, nil
+}
+
+func writeCgroupFile(cgroup string, name string, value string) error {
+	return writeFile(filepath.Join(cgroupRoot, cgroup, name), value)
+}
+
+func writeFile(path string, value string) error {
+	if err := os.WriteFile(path, []byte(value), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
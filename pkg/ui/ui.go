@@ -33,30 +33,30 @@ func GetStatusColor(status string) func(a ...interface{}) string {
 	}
 }
 
-func GetSafetyColor(safetyLevel string) func(a ...interface{}) string {
+func GetSafetyColor(safetyLevel process.SafetyLevel) func(a ...interface{}) string {
 	switch safetyLevel {
-	case "critical":
+	case process.SafetyCritical:
 		return RedBold
-	case "important":
+	case process.SafetyImportant:
 		return Yellow
-	case "safe":
+	case process.SafetySafe:
 		return Green
-	case "unknown":
+	case process.SafetyUnknown:
 		return White
 	default:
 		return fmt.Sprint
 	}
 }
 
-func GetSafetyIcon(safetyLevel string) string {
+func GetSafetyIcon(safetyLevel process.SafetyLevel) string {
 	switch safetyLevel {
-	case "critical":
+	case process.SafetyCritical:
 		return "🔴"
-	case "important":
+	case process.SafetyImportant:
 		return "🟡"
-	case "safe":
+	case process.SafetySafe:
 		return "🟢"
-	case "unknown":
+	case process.SafetyUnknown:
 		return "⚪"
 	default:
 		return "  "
@@ -75,6 +75,16 @@ func PrintTimestamp() {
 }
 
 func PrintProcessTable(processes []process.Process, limit int) {
+	printProcessTable(processes, limit, false)
+}
+
+// PrintExplainedProcessTable prints the process table with the rule that
+// decided each process's safety level.
+func PrintExplainedProcessTable(processes []process.Process, limit int) {
+	printProcessTable(processes, limit, true)
+}
+
+func printProcessTable(processes []process.Process, limit int, explain bool) {
 	if len(processes) == 0 {
 		fmt.Println(Yellow("No processes found"))
 		return
@@ -86,7 +96,11 @@ func PrintProcessTable(processes []process.Process, limit int) {
 
 	fmt.Printf("\n%s %s\n", Cyan("📊 Total processes:"), Bold(fmt.Sprintf("%d", len(processes))))
 	fmt.Println(Cyan("═══════════════════════════════════════════════════════════════════════════════════"))
-	fmt.Printf("%-8s %-30s %-15s %-15s\n", "PID", "NAME", "STATUS", "SAFETY")
+	if explain {
+		fmt.Printf("%-8s %-30s %-15s %-32s %-15s %s\n", "PID", "NAME", "STATUS", "UNIT", "SAFETY", "RULE")
+	} else {
+		fmt.Printf("%-8s %-30s %-15s %-32s %-15s\n", "PID", "NAME", "STATUS", "UNIT", "SAFETY")
+	}
 	fmt.Println(Cyan("═══════════════════════════════════════════════════════════════════════════════════"))
 
 	for i := 0; i < limit; i++ {
@@ -95,10 +109,25 @@ func PrintProcessTable(processes []process.Process, limit int) {
 		safetyColor := GetSafetyColor(p.SafetyLevel)
 		safetyIcon := GetSafetyIcon(p.SafetyLevel)
 
-		fmt.Printf("%-8d %-30s %-15s %s %s\n",
+		if explain {
+			matched := p.Classification.MatchedRule()
+			fmt.Printf("%-8d %-30s %-15s %-32s %s %-12s %s: %s\n",
+				p.PID,
+				p.Name,
+				statusColor(p.Status),
+				unitName(p.Unit),
+				safetyIcon,
+				safetyColor(p.SafetyLevel),
+				Cyan(matched.Rule),
+				matched.Detail)
+			continue
+		}
+
+		fmt.Printf("%-8d %-30s %-15s %-32s %s %s\n",
 			p.PID,
 			p.Name,
 			statusColor(p.Status),
+			unitName(p.Unit),
 			safetyIcon,
 			safetyColor(p.SafetyLevel))
 	}
@@ -108,6 +137,17 @@ func PrintProcessTable(processes []process.Process, limit int) {
 	}
 }
 
+// unitName formats a systemd unit for the process table.
+func unitName(unit string) string {
+	if unit == "" {
+		return "-"
+	}
+	if len(unit) > 32 {
+		return unit[:29] + "..."
+	}
+	return unit
+}
+
 func CreateProgressBar(max int, description string) *progressbar.ProgressBar {
 	return progressbar.NewOptions(max,
 		progressbar.OptionSetDescription(description),
@@ -124,7 +164,7 @@ func CreateProgressBar(max int, description string) *progressbar.ProgressBar {
 
 func PrintStats(processes []process.Process) {
 	statusStats := make(map[string]int)
-	safetyStats := make(map[string]int)
+	safetyStats := make(map[process.SafetyLevel]int)
 
 	for _, p := range processes {
 		statusStats[p.Status]++
@@ -143,12 +183,11 @@ func PrintStats(processes []process.Process) {
 	}
 
 	fmt.Println(Cyan("\n━━━ By Safety Level ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	safetyOrder := []string{"critical", "important", "safe", "unknown"}
-	for _, safety := range safetyOrder {
+	for _, safety := range process.SafetyLevels {
 		if count, ok := safetyStats[safety]; ok {
 			colorFunc := GetSafetyColor(safety)
 			icon := GetSafetyIcon(safety)
-			fmt.Printf("  %s %-15s %s\n", icon, colorFunc(safety+":"), Bold(fmt.Sprintf("%d", count)))
+			fmt.Printf("  %s %-15s %s\n", icon, colorFunc(string(safety)+":"), Bold(fmt.Sprintf("%d", count)))
 		}
 	}
 }